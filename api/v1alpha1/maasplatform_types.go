@@ -17,7 +17,12 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -29,16 +34,860 @@ type MaasPlatformSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 	// Policies are now configured via Tier resources that reference this MaasPlatform
+
+	// Profile selects the kind of cluster the platform runs on.
+	// openshift detects the cluster domain and uses the OpenShift gateway controller;
+	// kubernetes uses an existing GatewayClass and skips OpenShift-only resources.
+	// +kubebuilder:validation:Enum=openshift;kubernetes
+	// +kubebuilder:default=openshift
+	// +optional
+	Profile string `json:"profile,omitempty"`
+
+	// ClusterDomain is the base domain of the default gateway hostname (maas.<clusterDomain>).
+	// Detected from the OpenShift ingress config when empty, required with the kubernetes profile.
+	// +optional
+	ClusterDomain string `json:"clusterDomain,omitempty"`
+
+	// Gateway configures the platform gateway (hostname, listeners and TLS)
+	// +optional
+	Gateway *GatewayConfig `json:"gateway,omitempty"`
+
+	// MaasAPI configures the maas-api Deployment
+	// +optional
+	MaasAPI *MaasAPIConfig `json:"maasAPI,omitempty"`
+
+	// Components selects whether the operator manages each platform component, and how the
+	// optional components are handled: created by the operator, adopted from an existing
+	// installation, or disabled
+	// +optional
+	Components *ComponentsConfig `json:"components,omitempty"`
+
+	// Namespaces configures where the platform components are deployed.
+	// They cannot be changed after creation.
+	// +optional
+	Namespaces *NamespacesConfig `json:"namespaces,omitempty"`
+
+	// DeletionPolicy controls what happens to the managed resources when the MaasPlatform is deleted.
+	// Delete removes every resource the operator created, Retain leaves them in place.
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+
+	// ConflictPolicy controls what happens when server-side apply finds fields owned by another field manager.
+	// Force takes ownership of the fields. Report leaves the conflicting object as it is, none of its
+	// fields are updated, and lists the conflict in the status.
+	// +kubebuilder:validation:Enum=Force;Report
+	// +kubebuilder:default=Force
+	// +optional
+	ConflictPolicy string `json:"conflictPolicy,omitempty"`
+
+	// TierCleanup controls what happens to the tier ConfigMap and the gateway rate limit
+	// policies once no Tier targets the platform anymore
+	// +optional
+	TierCleanup *TierCleanupConfig `json:"tierCleanup,omitempty"`
+
+	// ManagementState controls whether the operator writes the platform objects.
+	// Managed applies the desired state. Plan only compares it with the cluster and reports
+	// the pending changes in status.plan and as Events, without writing any managed object.
+	// Unmanaged suspends the reconciliation of the platform and its Tiers, leaving every managed
+	// object as it is. Removed deletes the managed objects but keeps the MaasPlatform.
+	// A MaasPlatform deleted in the Plan or Unmanaged state keeps its finalizer until it is managed
+	// again, unless its deletionPolicy is Retain.
+	// The myapp.io.odh.maas/paused annotation suspends the reconciliation the same way as Unmanaged.
+	// +kubebuilder:validation:Enum=Managed;Plan;Unmanaged;Removed
+	// +kubebuilder:default=Managed
+	// +optional
+	ManagementState string `json:"managementState,omitempty"`
+
+	// Patches are applied to the rendered objects before they are applied to the cluster.
+	// They cover the settings the spec does not expose; the operator labels are kept.
+	// The tier ConfigMap and policies are written from the Tiers and cannot be patched.
+	// +optional
+	Patches []ObjectPatch `json:"patches,omitempty"`
+}
+
+// ObjectPatch is a patch for one of the objects the operator renders.
+type ObjectPatch struct {
+	// Target selects the rendered object to patch
+	Target PatchTarget `json:"target"`
+
+	// Type of the patch. StrategicMerge patches are partial objects; kinds without a
+	// strategic merge schema, such as the Gateway API and Kuadrant kinds, are merged as
+	// JSON merge patches. JSON6902 patches are lists of operations.
+	// +kubebuilder:validation:Enum=StrategicMerge;JSON6902
+	// +kubebuilder:default=StrategicMerge
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Patch is the patch document, in YAML or JSON
+	// +kubebuilder:validation:MinLength=1
+	Patch string `json:"patch"`
+}
+
+// PatchTarget identifies a rendered object by kind and name.
+type PatchTarget struct {
+	// Kind of the object (e.g. Deployment or Gateway)
+	// +kubebuilder:validation:MinLength=1
+	Kind string `json:"kind"`
+
+	// Name of the object as rendered, e.g. <platform name>-api for the maas-api Deployment
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace of the object, any namespace when empty
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Matches reports whether the target selects the object with the given kind, namespace and name
+func (t PatchTarget) Matches(kind, namespace, name string) bool {
+	return t.Kind == kind && t.Name == name && (t.Namespace == "" || t.Namespace == namespace)
+}
+
+// String returns the target as kind/name or kind/namespace/name
+func (t PatchTarget) String() string {
+	if t.Namespace == "" {
+		return t.Kind + "/" + t.Name
+	}
+	return t.Kind + "/" + t.Namespace + "/" + t.Name
+}
+
+// Patch types for ObjectPatch.Type.
+const (
+	PatchTypeStrategicMerge = "StrategicMerge"
+	PatchTypeJSON6902       = "JSON6902"
+)
+
+// TierCleanupConfig defines how the Tier generated resources are cleaned up.
+type TierCleanupConfig struct {
+	// Policy is Delete to remove the ConfigMap and policies, or Reset to keep them
+	// with no tiers and the default limits below
+	// +kubebuilder:validation:Enum=Delete;Reset
+	// +kubebuilder:default=Delete
+	// +optional
+	Policy string `json:"policy,omitempty"`
+
+	// DefaultRateLimits is written to the RateLimitPolicy on Reset
+	// +optional
+	DefaultRateLimits *TierRateLimitConfig `json:"defaultRateLimits,omitempty"`
+
+	// DefaultTokenRateLimits is written to the TokenRateLimitPolicy on Reset
+	// +optional
+	DefaultTokenRateLimits *TierTokenRateLimitConfig `json:"defaultTokenRateLimits,omitempty"`
+}
+
+// Deletion policies for MaasPlatformSpec.DeletionPolicy.
+const (
+	DeletionPolicyDelete = "Delete"
+	DeletionPolicyRetain = "Retain"
+)
+
+// Tier cleanup policies for TierCleanupConfig.Policy.
+const (
+	TierCleanupDelete = "Delete"
+	TierCleanupReset  = "Reset"
+)
+
+// Management states for MaasPlatformSpec.ManagementState and ManagedComponentConfig.ManagementState.
+const (
+	ManagementStateManaged   = "Managed"
+	ManagementStatePlan      = "Plan"
+	ManagementStateUnmanaged = "Unmanaged"
+	ManagementStateRemoved   = "Removed"
+)
+
+// PausedAnnotation suspends the reconciliation of a MaasPlatform and its Tiers while set to "true"
+const PausedAnnotation = "myapp.io.odh.maas/paused"
+
+// IsPaused reports whether the reconciliation of the platform is paused by the PausedAnnotation
+func (m *MaasPlatform) IsPaused() bool {
+	return m.Annotations[PausedAnnotation] == "true"
+}
+
+// NamingAnnotation selects how the objects generated for a MaasPlatform are named. The operator sets
+// it to NamingLegacy on a platform deployed before names were derived from the platform name, so the
+// platform keeps its gateway, maas-api database and policies. It cannot be changed afterwards.
+const NamingAnnotation = "myapp.io.odh.maas/naming"
+
+// NamingLegacy keeps the fixed names of the first releases, such as maas-default-gateway and maas-api.
+// Only one MaasPlatform can use them.
+const NamingLegacy = "legacy"
+
+// UsesLegacyNames reports whether the generated objects of the platform keep the legacy names
+func (m *MaasPlatform) UsesLegacyNames() bool {
+	return m.Annotations[NamingAnnotation] == NamingLegacy
+}
+
+// Conflict policies for MaasPlatformSpec.ConflictPolicy.
+const (
+	ConflictPolicyForce  = "Force"
+	ConflictPolicyReport = "Report"
+)
+
+// Platform profiles for MaasPlatformSpec.Profile.
+const (
+	ProfileOpenShift  = "openshift"
+	ProfileKubernetes = "kubernetes"
+)
+
+// DefaultGatewayClassName is the GatewayClass the operator creates and uses on OpenShift
+const DefaultGatewayClassName = "openshift-default"
+
+// IsOpenShift reports whether the platform uses the openshift profile, the default
+func (s *MaasPlatformSpec) IsOpenShift() bool {
+	return s.Profile != ProfileKubernetes
+}
+
+// GatewayClassName returns the GatewayClass of the platform gateway, defaulting to openshift-default
+func (s *MaasPlatformSpec) GatewayClassName() string {
+	if s.Gateway != nil && s.Gateway.GatewayClassName != "" {
+		return s.Gateway.GatewayClassName
+	}
+	return DefaultGatewayClassName
+}
+
+// Validate checks the parts of a MaasPlatform spec that depend on each other.
+// It does not look at other objects in the cluster.
+func (s *MaasPlatformSpec) Validate() error {
+	if !s.IsOpenShift() {
+		if s.ClusterDomain == "" {
+			return fmt.Errorf("clusterDomain is required with the kubernetes profile")
+		}
+		if s.Gateway == nil || s.Gateway.GatewayClassName == "" {
+			return fmt.Errorf("gateway.gatewayClassName is required with the kubernetes profile")
+		}
+	}
+	if s.MaasAPI != nil {
+		if err := s.MaasAPI.validateAvailability(s.UsesExternalDatabase()); err != nil {
+			return err
+		}
+	}
+	if s.UsesExternalDatabase() {
+		postgreSQL := s.MaasAPI.Database.PostgreSQL
+		if postgreSQL == nil || postgreSQL.URLSecretRef.Name == "" || postgreSQL.URLSecretRef.Key == "" {
+			return fmt.Errorf("maasAPI.database.postgreSQL.urlSecretRef name and key are required with the PostgreSQL database")
+		}
+	}
+	return nil
+}
+
+// ComponentsConfig configures the components of the platform.
+type ComponentsConfig struct {
+	// MaasAPI is the maas-api Deployment and the objects around it
+	// +optional
+	MaasAPI *ManagedComponentConfig `json:"maasAPI,omitempty"`
+
+	// Networking is the platform gateway, its routes, the GatewayClass and Kuadrant
+	// +optional
+	Networking *ManagedComponentConfig `json:"networking,omitempty"`
+
+	// GatewayAuthPolicy is the authentication policy of the platform gateway
+	// +optional
+	GatewayAuthPolicy *ManagedComponentConfig `json:"gatewayAuthPolicy,omitempty"`
+
+	// TierPolicies is the tier ConfigMap and the gateway rate limit policies written from the Tiers
+	// +optional
+	TierPolicies *ManagedComponentConfig `json:"tierPolicies,omitempty"`
+
+	// GatewayClass is the openshift-default GatewayClass, only used with the openshift profile
+	// +optional
+	GatewayClass *ComponentConfig `json:"gatewayClass,omitempty"`
+
+	// InferenceGateway is the openshift-ai-inference Gateway, only used with the openshift profile
+	// +optional
+	InferenceGateway *ComponentConfig `json:"inferenceGateway,omitempty"`
+
+	// Kuadrant is the kuadrant instance in the Kuadrant namespace
+	// +optional
+	Kuadrant *ComponentConfig `json:"kuadrant,omitempty"`
+}
+
+// ComponentConfig defines how an optional component is handled.
+type ComponentConfig struct {
+	// Mode is Create to deploy and update the component, Adopt to use an existing object
+	// that the operator only checks for, or Disabled to leave it out entirely.
+	// Adopted and disabled objects are never modified or deleted by the operator.
+	// +kubebuilder:validation:Enum=Create;Adopt;Disabled
+	// +kubebuilder:default=Create
+	// +optional
+	Mode string `json:"mode,omitempty"`
+}
+
+// ManagedComponentConfig defines whether the operator manages a platform component.
+type ManagedComponentConfig struct {
+	// ManagementState is Managed to apply the component, Unmanaged to leave its objects as they
+	// are, for instance while they are patched by hand during an incident, or Removed to delete them.
+	// Optional components the operator does not create, and shared resources other platforms
+	// still use, are never removed.
+	// +kubebuilder:validation:Enum=Managed;Unmanaged;Removed
+	// +kubebuilder:default=Managed
+	// +optional
+	ManagementState string `json:"managementState,omitempty"`
+}
+
+// Component modes for ComponentConfig.Mode.
+const (
+	ComponentModeCreate   = "Create"
+	ComponentModeAdopt    = "Adopt"
+	ComponentModeDisabled = "Disabled"
+)
+
+// ComponentMode returns the mode of an optional component, defaulting to Create
+func (s *MaasPlatformSpec) ComponentMode(name string) string {
+	var config *ComponentConfig
+	if s.Components != nil {
+		switch name {
+		case ComponentGatewayClass:
+			config = s.Components.GatewayClass
+		case ComponentInferenceGateway:
+			config = s.Components.InferenceGateway
+		case ComponentKuadrant:
+			config = s.Components.Kuadrant
+		}
+	}
+	if config == nil || config.Mode == "" {
+		return ComponentModeCreate
+	}
+	return config.Mode
 }
 
+// ComponentManagementState returns the management state of a platform component (maas-api,
+// networking, gateway-auth-policy or tier-policies). An Unmanaged or Removed platform applies
+// to every component, otherwise it defaults to Managed.
+func (s *MaasPlatformSpec) ComponentManagementState(name string) string {
+	switch s.ManagementState {
+	case ManagementStateUnmanaged, ManagementStateRemoved:
+		return s.ManagementState
+	}
+
+	var config *ManagedComponentConfig
+	if s.Components != nil {
+		switch name {
+		case ComponentMaasAPI:
+			config = s.Components.MaasAPI
+		case ComponentNetworking:
+			config = s.Components.Networking
+		case ComponentGatewayAuthPolicy:
+			config = s.Components.GatewayAuthPolicy
+		case ComponentTierPolicies:
+			config = s.Components.TierPolicies
+		}
+	}
+	if config == nil || config.ManagementState == "" {
+		return ManagementStateManaged
+	}
+	return config.ManagementState
+}
+
+// NamespacesConfig defines the namespaces of the platform components.
+type NamespacesConfig struct {
+	// API is the namespace of maas-api and the tier ConfigMap
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:default=maas-api
+	// +optional
+	API string `json:"api,omitempty"`
+
+	// Gateway is the namespace of the platform gateway and its policies
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:default=openshift-ingress
+	// +optional
+	Gateway string `json:"gateway,omitempty"`
+
+	// Kuadrant is the namespace of the Kuadrant instance
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:default=kuadrant-system
+	// +optional
+	Kuadrant string `json:"kuadrant,omitempty"`
+}
+
+// Default namespaces of the platform components.
+const (
+	DefaultAPINamespace      = "maas-api"
+	DefaultGatewayNamespace  = "openshift-ingress"
+	DefaultKuadrantNamespace = "kuadrant-system"
+)
+
+// APINamespace returns the namespace of maas-api, defaulting to maas-api.
+func (s *MaasPlatformSpec) APINamespace() string {
+	if s.Namespaces != nil && s.Namespaces.API != "" {
+		return s.Namespaces.API
+	}
+	return DefaultAPINamespace
+}
+
+// GatewayNamespace returns the namespace of the gateway, defaulting to openshift-ingress.
+func (s *MaasPlatformSpec) GatewayNamespace() string {
+	if s.Namespaces != nil && s.Namespaces.Gateway != "" {
+		return s.Namespaces.Gateway
+	}
+	return DefaultGatewayNamespace
+}
+
+// KuadrantNamespace returns the namespace of the Kuadrant instance, defaulting to kuadrant-system.
+func (s *MaasPlatformSpec) KuadrantNamespace() string {
+	if s.Namespaces != nil && s.Namespaces.Kuadrant != "" {
+		return s.Namespaces.Kuadrant
+	}
+	return DefaultKuadrantNamespace
+}
+
+// MaasAPIConfig defines the workload settings of the maas-api Deployment.
+// Unset fields keep the values of the embedded manifest.
+type MaasAPIConfig struct {
+	// Image is the maas-api image reference, with a tag or a digest
+	// (e.g. quay.io/opendatahub/maas-api:v0.1.0 or quay.io/opendatahub/maas-api@sha256:...)
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// ImagePullPolicy of the maas-api container
+	// +kubebuilder:validation:Enum=Always;IfNotPresent;Never
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// ImagePullSecrets used to pull the maas-api image
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// Replicas of the maas-api Deployment. Leave unset with autoscaling.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Autoscaling adds a HorizontalPodAutoscaler for maas-api, which then owns the replicas
+	// +optional
+	Autoscaling *MaasAPIAutoscalingConfig `json:"autoscaling,omitempty"`
+
+	// PodDisruptionBudget adds a PodDisruptionBudget for the maas-api pods
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetConfig `json:"podDisruptionBudget,omitempty"`
+
+	// TopologySpreadConstraints of the maas-api pods.
+	// Constraints without a labelSelector select the maas-api pods of the platform.
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// Resources of the maas-api container
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Env is added to the maas-api container, replacing variables with the same name
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// NodeSelector of the maas-api pods
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations of the maas-api pods
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity of the maas-api pods
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// PriorityClassName of the maas-api pods
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// Database selects where maas-api keeps its state
+	// +optional
+	Database *MaasAPIDatabaseConfig `json:"database,omitempty"`
+}
+
+// MaasAPIAutoscalingConfig defines the HorizontalPodAutoscaler of maas-api.
+type MaasAPIAutoscalingConfig struct {
+	// MinReplicas is the lower limit of replicas
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=2
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the upper limit of replicas
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// TargetCPUUtilizationPercentage is the average CPU utilization of the pods, relative to their requests
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=80
+	// +optional
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+}
+
+// PodDisruptionBudgetConfig defines the PodDisruptionBudget of maas-api.
+// Set at most one of MinAvailable and MaxUnavailable; without either, one pod may be unavailable.
+type PodDisruptionBudgetConfig struct {
+	// MinAvailable pods during voluntary disruptions, as a number or a percentage
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// MaxUnavailable pods during voluntary disruptions, as a number or a percentage
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// MaasAPIDatabaseConfig selects the database of maas-api.
+type MaasAPIDatabaseConfig struct {
+	// Type is Embedded for SQLite on a ReadWriteOnce PVC, which keeps maas-api on a single node,
+	// or PostgreSQL for an external database. Switching to PostgreSQL deletes the PVC.
+	// +kubebuilder:validation:Enum=Embedded;PostgreSQL
+	// +kubebuilder:default=Embedded
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Embedded configures the PVC of the embedded database
+	// +optional
+	Embedded *EmbeddedDatabaseConfig `json:"embedded,omitempty"`
+
+	// PostgreSQL configures the external database, required with the PostgreSQL type
+	// +optional
+	PostgreSQL *PostgreSQLDatabaseConfig `json:"postgreSQL,omitempty"`
+}
+
+// EmbeddedDatabaseConfig defines the PVC of the embedded database.
+type EmbeddedDatabaseConfig struct {
+	// StorageClassName of the PVC, the cluster default when empty.
+	// It only applies when the PVC is created.
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+
+	// Size of the PVC, 1Gi by default. An existing PVC is only ever expanded,
+	// which requires a storage class that allows volume expansion.
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+}
+
+// PostgreSQLDatabaseConfig defines the connection to an external PostgreSQL database.
+type PostgreSQLDatabaseConfig struct {
+	// URLSecretRef selects the key of a Secret in the API namespace holding the connection URL,
+	// e.g. postgres://maas:<password>@postgres.example.com:5432/maas?sslmode=require
+	URLSecretRef corev1.SecretKeySelector `json:"urlSecretRef"`
+}
+
+// Database types for MaasAPIDatabaseConfig.Type.
+const (
+	DatabaseTypeEmbedded   = "Embedded"
+	DatabaseTypePostgreSQL = "PostgreSQL"
+)
+
+// validateAvailability checks the replica, autoscaling and disruption budget settings of maas-api
+func (c *MaasAPIConfig) validateAvailability(externalDatabase bool) error {
+	if c.Autoscaling != nil {
+		if c.Replicas != nil {
+			return fmt.Errorf("maasAPI.replicas cannot be set with maasAPI.autoscaling, the HorizontalPodAutoscaler owns the replicas")
+		}
+		if c.Autoscaling.MinReplicas != nil && *c.Autoscaling.MinReplicas > c.Autoscaling.MaxReplicas {
+			return fmt.Errorf("maasAPI.autoscaling.minReplicas cannot be greater than maxReplicas")
+		}
+		if !externalDatabase {
+			return fmt.Errorf("maasAPI.autoscaling requires the PostgreSQL database, the embedded database PVC can only be mounted on one node")
+		}
+	}
+	if c.PodDisruptionBudget != nil && c.PodDisruptionBudget.MinAvailable != nil && c.PodDisruptionBudget.MaxUnavailable != nil {
+		return fmt.Errorf("maasAPI.podDisruptionBudget accepts only one of minAvailable and maxUnavailable")
+	}
+	return nil
+}
+
+// UsesExternalDatabase reports whether maas-api uses an external PostgreSQL database
+func (s *MaasPlatformSpec) UsesExternalDatabase() bool {
+	return s.MaasAPI != nil && s.MaasAPI.Database != nil && s.MaasAPI.Database.Type == DatabaseTypePostgreSQL
+}
+
+// GatewayConfig defines how the MaaS gateway is exposed.
+type GatewayConfig struct {
+	// GatewayClassName of the platform gateway, for example istio or eg for Envoy Gateway.
+	// Defaults to openshift-default, which the operator creates with the openshift profile;
+	// required with the kubernetes profile.
+	// +optional
+	GatewayClassName string `json:"gatewayClassName,omitempty"`
+
+	// Hostname served by the gateway listeners
+	// Default: "maas.<cluster domain>"
+	// +optional
+	Hostname string `json:"hostname,omitempty"`
+
+	// Additional listeners appended to the default http and https listeners
+	// +optional
+	Listeners []GatewayListener `json:"listeners,omitempty"`
+
+	// TLS configuration for the https listener
+	// +optional
+	TLS *GatewayTLSConfig `json:"tls,omitempty"`
+
+	// RedirectHTTPToHTTPS redirects plain HTTP requests on the http listener to HTTPS
+	// +optional
+	RedirectHTTPToHTTPS bool `json:"redirectHTTPToHTTPS,omitempty"`
+}
+
+// GatewayListener defines an additional listener on the MaaS gateway.
+type GatewayListener struct {
+	// Name of the listener, must be unique within the gateway
+	Name string `json:"name"`
+
+	// Hostname served by the listener
+	// If empty, defaults to the gateway hostname
+	// +optional
+	Hostname string `json:"hostname,omitempty"`
+
+	// Port the listener binds to
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// Protocol of the listener (HTTP or HTTPS)
+	// +kubebuilder:validation:Enum=HTTP;HTTPS
+	Protocol string `json:"protocol"`
+
+	// TLS secret for HTTPS listeners
+	// If empty, the gateway TLS secret is used
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+}
+
+// GatewayTLSConfig defines where the gateway certificate comes from.
+// Set either SecretName for an existing certificate, or CertManagerIssuer to have
+// cert-manager issue one into SecretName.
+type GatewayTLSConfig struct {
+	// Name of the TLS Secret in the gateway namespace
+	// Default: "default-gateway-tls"
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// cert-manager issuer used to issue the gateway certificate
+	// +optional
+	CertManagerIssuer *CertManagerIssuerRef `json:"certManagerIssuer,omitempty"`
+}
+
+// CertManagerIssuerRef references a cert-manager Issuer or ClusterIssuer.
+type CertManagerIssuerRef struct {
+	// Name of the issuer
+	Name string `json:"name"`
+
+	// Kind of the issuer (Issuer or ClusterIssuer)
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	// +kubebuilder:default=ClusterIssuer
+	// +optional
+	Kind string `json:"kind,omitempty"`
+}
+
+// Condition types reported on MaasPlatform resources.
+const (
+	// ConditionReady indicates that all managed resources were applied and are available
+	ConditionReady = "Ready"
+	// ConditionProgressing indicates that managed resources are still rolling out
+	ConditionProgressing = "Progressing"
+	// ConditionDegraded indicates that the last reconciliation failed
+	ConditionDegraded = "Degraded"
+	// ConditionPrerequisitesMissing indicates that APIs the platform relies on are not installed
+	ConditionPrerequisitesMissing = "PrerequisitesMissing"
+	// ConditionChangesPending indicates that the Plan management state holds back changes, see status.plan
+	ConditionChangesPending = "ChangesPending"
+	// ConditionSuspended indicates that the platform, or some of its components, are not reconciled
+	ConditionSuspended = "Suspended"
+)
+
+// Component names reported in MaasPlatformStatus.Components.
+const (
+	ComponentMaasAPI           = "maas-api"
+	ComponentNetworking        = "networking"
+	ComponentGatewayAuthPolicy = "gateway-auth-policy"
+	ComponentGatewayClass      = "gateway-class"
+	ComponentInferenceGateway  = "inference-gateway"
+	ComponentKuadrant          = "kuadrant"
+	ComponentTierPolicies      = "tier-policies"
+)
+
+// Deployment phases reported in MaasPlatformStatus.Phase, in the order they are gone through.
+// Each phase waits for the previous one to be ready.
+const (
+	// DeploymentPhaseNamespaces creates the namespaces of the platform components
+	DeploymentPhaseNamespaces = "Namespaces"
+	// DeploymentPhaseKuadrant applies the Kuadrant instance and waits for it to be Ready
+	DeploymentPhaseKuadrant = "Kuadrant"
+	// DeploymentPhaseGateway applies the GatewayClass and the gateways and waits for the platform gateway to be Programmed
+	DeploymentPhaseGateway = "Gateway"
+	// DeploymentPhaseMaasAPI applies maas-api and waits for its Deployment to be Available
+	DeploymentPhaseMaasAPI = "MaasAPI"
+	// DeploymentPhasePolicies applies the authentication policies; the Tier policies are written from this phase on
+	DeploymentPhasePolicies = "Policies"
+	// DeploymentPhaseComplete is reached once every phase is done
+	DeploymentPhaseComplete = "Complete"
+)
+
+// Component phases reported in ComponentStatus.Phase.
+const (
+	ComponentPhaseReady       = "Ready"
+	ComponentPhaseProgressing = "Progressing"
+	ComponentPhaseFailed      = "Failed"
+	ComponentPhaseSuspended   = "Suspended"
+	ComponentPhaseRemoved     = "Removed"
+)
+
 // MaasPlatformStatus defines the observed state of MaasPlatform.
 type MaasPlatformStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
+
+	// ObservedGeneration is the most recent generation reconciled by the operator
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest observations of the platform (Ready, Progressing, Degraded, PrerequisitesMissing)
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Phase is the deployment phase the platform is in. The phases before it are done; while it
+	// is not Complete, the Progressing condition tells what the phase is waiting for.
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// PhaseTransitionTime is when the platform entered its current phase
+	// +optional
+	PhaseTransitionTime *metav1.Time `json:"phaseTransitionTime,omitempty"`
+
+	// Components reports the state of each component deployed for the platform
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Components []ComponentStatus `json:"components,omitempty"`
+
+	// ClusterDomain is the cluster domain used to render the platform manifests
+	// +optional
+	ClusterDomain string `json:"clusterDomain,omitempty"`
+
+	// ManagedResources is the inventory of resources created for the platform.
+	// It is used to clean up cluster-scoped and cross-namespace resources on deletion.
+	// +optional
+	ManagedResources []ManagedResource `json:"managedResources,omitempty"`
+
+	// Conflicts lists the resources that were not updated at all because another field manager
+	// owns some of the fields applied to them. Only reported with the Report conflict policy.
+	// +optional
+	Conflicts []ApplyConflict `json:"conflicts,omitempty"`
+
+	// Patches reports the outcome of each entry of spec.patches, in the same order
+	// +optional
+	Patches []PatchStatus `json:"patches,omitempty"`
+
+	// Plan lists the changes the operator would make, while the management state is Plan
+	// +optional
+	Plan *Plan `json:"plan,omitempty"`
+}
+
+// Plan summarizes the changes the operator would make to bring the cluster to the desired state.
+type Plan struct {
+	// Summary counts the planned changes by action
+	Summary string `json:"summary"`
+
+	// Changes lists the objects that would be created, updated or deleted, in apply order
+	// +optional
+	Changes []PlannedChange `json:"changes,omitempty"`
+}
+
+// PlannedChange is a change the operator would make to a single object.
+type PlannedChange struct {
+	ManagedResource `json:",inline"`
+
+	// Action is Create, Update, Delete, or Conflict when the Report conflict policy would
+	// leave the object alone because another field manager owns some of its fields
+	// +kubebuilder:validation:Enum=Create;Update;Delete;Conflict
+	Action string `json:"action"`
+
+	// Fields lists the paths of the fields an update changes
+	// +optional
+	Fields []string `json:"fields,omitempty"`
+
+	// Message gives details, such as the fields taken over from another field manager
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// Planned actions reported in PlannedChange.Action.
+const (
+	PlannedActionCreate   = "Create"
+	PlannedActionUpdate   = "Update"
+	PlannedActionDelete   = "Delete"
+	PlannedActionConflict = "Conflict"
+)
+
+// PatchStatus reports the outcome of a spec.patches entry.
+type PatchStatus struct {
+	// Target of the patch, as kind/name or kind/namespace/name
+	Target string `json:"target"`
+
+	// State of the patch (Applied, Failed, Skipped, Unmatched). Skipped patches only match
+	// objects the operator does not apply, since their component is not managed or not created.
+	State string `json:"state"`
+
+	// Human-readable details about the state
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// Patch states reported in PatchStatus.State.
+const (
+	PatchStateApplied   = "Applied"
+	PatchStateFailed    = "Failed"
+	PatchStateSkipped   = "Skipped"
+	PatchStateUnmatched = "Unmatched"
+)
+
+// ManagedResource identifies a resource created by the operator.
+type ManagedResource struct {
+	// APIVersion of the resource
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the resource
+	Kind string `json:"kind"`
+
+	// Namespace of the resource, empty for cluster-scoped resources
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the resource
+	Name string `json:"name"`
+}
+
+// ApplyConflict reports a resource that was not updated because some of its fields are owned by
+// another field manager.
+type ApplyConflict struct {
+	ManagedResource `json:",inline"`
+
+	// Message is the conflict reported by the API server
+	Message string `json:"message"`
+}
+
+// ComponentStatus reports the state of a single platform component.
+type ComponentStatus struct {
+	// Name of the component (maas-api, networking, gateway-auth-policy, gateway-class, inference-gateway, kuadrant)
+	Name string `json:"name"`
+
+	// Phase of the component (Ready, Progressing, Failed, Suspended, Removed)
+	Phase string `json:"phase"`
+
+	// Mode of optional components (Create, Adopt, Disabled)
+	// +optional
+	Mode string `json:"mode,omitempty"`
+
+	// Human-readable details about the phase
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Domain",type=string,JSONPath=`.status.clusterDomain`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // MaasPlatform is the Schema for the maasplatforms API.
 type MaasPlatform struct {
@@ -17,6 +17,10 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -31,6 +35,27 @@ type TierSpec struct {
 	// TargetRef references the MaasPlatform this tier applies to
 	TargetRef MaasPlatformTargetRef `json:"targetRef"`
 
+	// Level is the priority of the tier. When a user belongs to several tiers,
+	// the tier with the highest level wins. Levels above 0 must be unique per MaasPlatform,
+	// Tiers left at 0 are ordered by name.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Level int32 `json:"level,omitempty"`
+
+	// Groups whose members are assigned to this tier
+	// If groups, users and serviceAccounts are all empty, defaults to ["tier-<name>-users"]
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+
+	// Users assigned to this tier by user name, in addition to the members of the groups.
+	// A user listed by several tiers belongs to the one with the highest level.
+	// +optional
+	Users []string `json:"users,omitempty"`
+
+	// ServiceAccounts assigned to this tier, in addition to the members of the groups
+	// +optional
+	ServiceAccounts []ServiceAccountRef `json:"serviceAccounts,omitempty"`
+
 	// Rate limits for requests (HTTP requests per time window)
 	// +optional
 	RateLimits *TierRateLimitConfig `json:"rateLimits,omitempty"`
@@ -41,8 +66,120 @@ type TierSpec struct {
 
 	// Models that this tier applies to
 	// If empty, applies to all models. If specified, only these models are affected.
+	// Names are matched against the name and spec.model.name of LLMInferenceServices.
 	// +optional
 	Models []string `json:"models,omitempty"`
+
+	// ModelLimits override the tier limits for individual models
+	// +optional
+	ModelLimits []TierModelLimit `json:"modelLimits,omitempty"`
+}
+
+// TierModelLimit defines the limits of a tier for a single model.
+// Set either Model or LLMInferenceServiceRef.
+type TierModelLimit struct {
+	// Model is the model name, matched like the entries of spec.models
+	// +optional
+	Model string `json:"model,omitempty"`
+
+	// LLMInferenceServiceRef references the LLMInferenceService serving the model
+	// +optional
+	LLMInferenceServiceRef *LLMInferenceServiceRef `json:"llmInferenceServiceRef,omitempty"`
+
+	// Rate limits for requests to this model
+	// +optional
+	RateLimits *TierRateLimitConfig `json:"rateLimits,omitempty"`
+
+	// Token rate limits for this model
+	// +optional
+	TokenRateLimits *TierTokenRateLimitConfig `json:"tokenRateLimits,omitempty"`
+}
+
+// LLMInferenceServiceRef references a KServe LLMInferenceService.
+type LLMInferenceServiceRef struct {
+	// Name of the LLMInferenceService
+	Name string `json:"name"`
+
+	// Namespace of the LLMInferenceService
+	// If empty, defaults to the same namespace as the Tier resource
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ServiceAccountRef references a ServiceAccount.
+type ServiceAccountRef struct {
+	// Name of the ServiceAccount
+	Name string `json:"name"`
+
+	// Namespace of the ServiceAccount
+	// If empty, defaults to the same namespace as the Tier resource
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Validate checks the parts of a Tier spec that Kuadrant would otherwise reject.
+// It does not look at other objects in the cluster.
+func (s *TierSpec) Validate() error {
+	for _, group := range s.Groups {
+		if strings.TrimSpace(group) == "" {
+			return fmt.Errorf("groups must not contain empty entries")
+		}
+	}
+	for _, user := range s.Users {
+		if strings.TrimSpace(user) == "" {
+			return fmt.Errorf("users must not contain empty entries")
+		}
+	}
+	for i, serviceAccount := range s.ServiceAccounts {
+		if serviceAccount.Name == "" {
+			return fmt.Errorf("serviceAccounts[%d].name must not be empty", i)
+		}
+	}
+
+	if s.RateLimits != nil {
+		if err := validateRate("rateLimits", s.RateLimits.Limit, s.RateLimits.Window); err != nil {
+			return err
+		}
+	}
+
+	if s.TokenRateLimits != nil {
+		if err := validateRate("tokenRateLimits", s.TokenRateLimits.Limit, s.TokenRateLimits.Window); err != nil {
+			return err
+		}
+	}
+
+	for i, modelLimit := range s.ModelLimits {
+		field := fmt.Sprintf("modelLimits[%d]", i)
+		if (modelLimit.Model == "") == (modelLimit.LLMInferenceServiceRef == nil) {
+			return fmt.Errorf("%s must set exactly one of model or llmInferenceServiceRef", field)
+		}
+		if modelLimit.RateLimits == nil && modelLimit.TokenRateLimits == nil {
+			return fmt.Errorf("%s must set rateLimits or tokenRateLimits", field)
+		}
+		if modelLimit.RateLimits != nil {
+			if err := validateRate(field+".rateLimits", modelLimit.RateLimits.Limit, modelLimit.RateLimits.Window); err != nil {
+				return err
+			}
+		}
+		if modelLimit.TokenRateLimits != nil {
+			if err := validateRate(field+".tokenRateLimits", modelLimit.TokenRateLimits.Limit, modelLimit.TokenRateLimits.Window); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateRate checks the limit and window of a rate limit
+func validateRate(field string, limit int32, window string) error {
+	if limit <= 0 {
+		return fmt.Errorf("%s.limit must be positive, got %d", field, limit)
+	}
+	if _, err := time.ParseDuration(window); err != nil {
+		return fmt.Errorf("%s.window %q is not a valid duration", field, window)
+	}
+	return nil
 }
 
 // MaasPlatformTargetRef references a MaasPlatform resource
@@ -56,6 +193,28 @@ type MaasPlatformTargetRef struct {
 	Namespace string `json:"namespace,omitempty"`
 }
 
+// TargetNamespace returns the namespace of the referenced MaasPlatform,
+// defaulting to the namespace of the Tier.
+func (t *Tier) TargetNamespace() string {
+	if t.Spec.TargetRef.Namespace != "" {
+		return t.Spec.TargetRef.Namespace
+	}
+	return t.Namespace
+}
+
+// Usernames returns the user names of the users and ServiceAccounts assigned to the Tier
+func (t *Tier) Usernames() []string {
+	usernames := append([]string(nil), t.Spec.Users...)
+	for _, serviceAccount := range t.Spec.ServiceAccounts {
+		namespace := serviceAccount.Namespace
+		if namespace == "" {
+			namespace = t.Namespace
+		}
+		usernames = append(usernames, fmt.Sprintf("system:serviceaccount:%s:%s", namespace, serviceAccount.Name))
+	}
+	return usernames
+}
+
 // TierRateLimitConfig defines rate limit configuration for requests.
 type TierRateLimitConfig struct {
 	// Maximum number of requests allowed
@@ -84,14 +243,78 @@ type TierTokenRateLimitConfig struct {
 	Counters []string `json:"counters,omitempty"`
 }
 
+// Condition types reported on Tier resources.
+const (
+	// TierConditionAccepted indicates that the Tier spec is valid and included in the platform policies
+	TierConditionAccepted = "Accepted"
+	// TierConditionTargetResolved indicates that the target MaasPlatform exists
+	TierConditionTargetResolved = "TargetResolved"
+	// TierConditionPolicyProgrammed indicates that the Tier limits were written to the gateway policies
+	TierConditionPolicyProgrammed = "PolicyProgrammed"
+	// TierConditionChangesPending indicates that the Plan management state of the platform holds back
+	// changes to the tier ConfigMap and policies, see status.plan
+	TierConditionChangesPending = "ChangesPending"
+	// TierConditionSuspended indicates that the tier policies of the platform are not reconciled
+	TierConditionSuspended = "Suspended"
+)
+
 // TierStatus defines the observed state of Tier.
 type TierStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
+
+	// ObservedGeneration is the most recent generation reconciled by the operator
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest observations of the Tier (Accepted, TargetResolved, PolicyProgrammed)
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Platform is the resolved MaasPlatform in <namespace>/<name> form
+	// +optional
+	Platform string `json:"platform,omitempty"`
+
+	// Limits lists the policy limit entries generated for this Tier
+	// +optional
+	Limits []TierLimitStatus `json:"limits,omitempty"`
+
+	// Plan lists the changes the operator would make to the tier ConfigMap and policies of the
+	// platform, while its management state is Plan
+	// +optional
+	Plan *Plan `json:"plan,omitempty"`
+}
+
+// TierLimitStatus describes a limit entry generated in a Kuadrant policy.
+type TierLimitStatus struct {
+	// Kind of the policy (RateLimitPolicy or TokenRateLimitPolicy)
+	Kind string `json:"kind"`
+
+	// Policy is the policy holding the entry in <namespace>/<name> form
+	Policy string `json:"policy"`
+
+	// Name of the limit entry in the policy
+	Name string `json:"name"`
+
+	// Effective limit
+	Limit int32 `json:"limit"`
+
+	// Effective time window
+	Window string `json:"window"`
+
+	// Model the entry is scoped to, empty for tier-wide entries
+	// +optional
+	Model string `json:"model,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Platform",type=string,JSONPath=`.status.platform`
+// +kubebuilder:printcolumn:name="Accepted",type=string,JSONPath=`.status.conditions[?(@.type=="Accepted")].status`
+// +kubebuilder:printcolumn:name="Programmed",type=string,JSONPath=`.status.conditions[?(@.type=="PolicyProgrammed")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // Tier is the Schema for the tiers API.
 type Tier struct {
@@ -0,0 +1,154 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+	"github.com/jland-redhat/maas-operator.git/pkg/render"
+)
+
+// fileList collects the values of a repeatable flag
+type fileList []string
+
+func (f *fileList) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *fileList) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// runRender implements `maas-operator render`: it reads a MaasPlatform, its Tiers and optionally
+// LLMInferenceServices from YAML files, and writes the objects the operator would apply to stdout
+func runRender(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var files fileList
+	var clusterDomain, namespace string
+	fs := flag.NewFlagSet("render", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(stderr, "Usage: maas-operator render -f platform.yaml [-f tiers.yaml ...]")
+		_, _ = fmt.Fprintln(stderr, "Prints the objects the operator would apply for a MaasPlatform and its Tiers.")
+		fs.PrintDefaults()
+	}
+	fs.Var(&files, "f", "A YAML file with a MaasPlatform, Tiers or LLMInferenceServices, - for stdin. Can be repeated.")
+	fs.StringVar(&clusterDomain, "cluster-domain", "",
+		"The cluster domain to render with when the MaasPlatform does not set spec.clusterDomain.")
+	fs.StringVar(&namespace, "namespace", "default", "The namespace of the input objects that do not set one.")
+	opts := zap.Options{
+		Development: true,
+		DestWriter:  stderr,
+	}
+	opts.BindFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if len(files) == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	// Logs go to stderr so stdout only carries the rendered objects
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	log := ctrl.Log.WithName("render")
+
+	in, err := readRenderInput(files, namespace, stdin)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "error: %v\n", err)
+		return 1
+	}
+	in.Cluster.Domain = clusterDomain
+
+	// Report what the operator would report on the cluster
+	_, rejected := render.PlatformTiers(in.Tiers, in.Platform)
+	for _, tier := range rejected {
+		log.Info("Rejecting invalid Tier",
+			"tier", tier.Name, "namespace", tier.Namespace, "reason", tier.Spec.Validate().Error())
+	}
+	if platform, err := render.RenderPlatform(in.Platform, in.Cluster); err == nil {
+		for _, status := range platform.Patches {
+			if status.State == myappv1alpha1.PatchStateUnmatched {
+				log.Info("Patch matched no rendered object", "target", status.Target)
+			}
+		}
+	}
+
+	objects, err := render.Render(in)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "error: failed to render MaasPlatform %s: %v\n", in.Platform.Name, err)
+		return 1
+	}
+
+	out := bufio.NewWriter(stdout)
+	if err := render.WriteYAML(out, objects); err != nil {
+		_, _ = fmt.Fprintf(stderr, "error: %v\n", err)
+		return 1
+	}
+	if err := out.Flush(); err != nil {
+		_, _ = fmt.Fprintf(stderr, "error: failed to write output: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// readRenderInput decodes the MaasPlatform, Tiers and LLMInferenceServices of the given files.
+// Exactly one MaasPlatform is expected.
+func readRenderInput(files []string, namespace string, stdin io.Reader) (render.Input, error) {
+	var in render.Input
+	for _, file := range files {
+		if err := readRenderFile(&in, file, namespace, stdin); err != nil {
+			return in, err
+		}
+	}
+
+	if in.Platform == nil {
+		return in, fmt.Errorf("no MaasPlatform found in %s", strings.Join(files, ", "))
+	}
+	return in, nil
+}
+
+// readRenderFile decodes every document of a file, or of stdin for -, into the render input
+func readRenderFile(in *render.Input, file, namespace string, stdin io.Reader) error {
+	reader := stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		reader = f
+	}
+
+	if err := render.ReadInput(in, reader, namespace); err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+	return nil
+}
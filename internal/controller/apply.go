@@ -0,0 +1,62 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+)
+
+// fieldManager is the server-side apply field manager for every object written by the operator
+const fieldManager = "maas-operator"
+
+// applyObject server-side applies the desired state of an object under the operator field manager.
+// The object must carry its apiVersion and kind. Fields set by other managers are left alone
+// unless force is set, in which case the operator takes them over.
+func applyObject(ctx context.Context, c client.Client, obj client.Object, force bool) error {
+	// Apply requests describe intent, not a stored revision
+	obj.SetResourceVersion("")
+	obj.SetManagedFields(nil)
+
+	opts := []client.PatchOption{client.FieldOwner(fieldManager)}
+	if force {
+		opts = append(opts, client.ForceOwnership)
+	}
+	return c.Patch(ctx, obj, client.Apply, opts...)
+}
+
+// forceConflicts reports whether apply conflicts are forced for a MaasPlatform
+func forceConflicts(maasPlatform *myappv1alpha1.MaasPlatform) bool {
+	return maasPlatform.Spec.ConflictPolicy != myappv1alpha1.ConflictPolicyReport
+}
+
+// recordApplyConflict lists an object that was not updated at all because of a conflict
+func recordApplyConflict(maasPlatform *myappv1alpha1.MaasPlatform, obj *unstructured.Unstructured, err error) {
+	maasPlatform.Status.Conflicts = append(maasPlatform.Status.Conflicts, myappv1alpha1.ApplyConflict{
+		ManagedResource: myappv1alpha1.ManagedResource{
+			APIVersion: obj.GetAPIVersion(),
+			Kind:       obj.GetKind(),
+			Namespace:  obj.GetNamespace(),
+			Name:       obj.GetName(),
+		},
+		Message: err.Error(),
+	})
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+)
+
+// reconcileOptionalComponent handles an embedded object of an optional component according
+// to its mode. It returns true when the object must not be applied: disabled components are
+// skipped and adopted ones only have to exist. Neither stays in the inventory, so the
+// operator never deletes them.
+func (r *MaasPlatformReconciler) reconcileOptionalComponent(ctx context.Context, obj *unstructured.Unstructured, maasPlatform *myappv1alpha1.MaasPlatform, component string) (bool, error) {
+	mode := maasPlatform.Spec.ComponentMode(component)
+	switch mode {
+	case myappv1alpha1.ComponentModeDisabled:
+		forgetManagedResource(maasPlatform, obj)
+		setOptionalComponentStatus(maasPlatform, component, mode, myappv1alpha1.ComponentPhaseReady, "Disabled, not deployed by the operator")
+		return true, nil
+	case myappv1alpha1.ComponentModeAdopt:
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(obj.GroupVersionKind())
+		err := r.Get(ctx, client.ObjectKeyFromObject(obj), existing)
+		if errors.IsNotFound(err) {
+			err = fmt.Errorf("adopted %s %s does not exist", obj.GetKind(), objectKeyString(obj))
+			setOptionalComponentStatus(maasPlatform, component, mode, myappv1alpha1.ComponentPhaseFailed, err.Error())
+			return true, err
+		} else if err != nil {
+			return true, fmt.Errorf("failed to check adopted %s %s: %w", obj.GetKind(), objectKeyString(obj), err)
+		}
+		forgetManagedResource(maasPlatform, obj)
+		setOptionalComponentStatus(maasPlatform, component, mode, myappv1alpha1.ComponentPhaseReady, "Using the existing object")
+		return true, nil
+	default:
+		setOptionalComponentStatus(maasPlatform, component, mode, myappv1alpha1.ComponentPhaseReady, "Managed by the operator")
+		return false, nil
+	}
+}
+
+// setOptionalComponentStatus records the phase of an optional component along with its mode
+func setOptionalComponentStatus(maasPlatform *myappv1alpha1.MaasPlatform, name, mode, phase, message string) {
+	setComponentStatus(maasPlatform, name, phase, message)
+	for i := range maasPlatform.Status.Components {
+		if maasPlatform.Status.Components[i].Name == name {
+			maasPlatform.Status.Components[i].Mode = mode
+		}
+	}
+}
+
+// objectKeyString formats the namespace and name of an object, or only the name for cluster-scoped objects
+func objectKeyString(obj client.Object) string {
+	if obj.GetNamespace() == "" {
+		return obj.GetName()
+	}
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+	"github.com/jland-redhat/maas-operator.git/pkg/render"
+)
+
+// maasPlatformFinalizer guards the cleanup of resources that owner references cannot cover
+const maasPlatformFinalizer = "myapp.io.odh.maas/finalizer"
+
+// deletionOrder ranks kinds so that policies go before the routes and gateways they
+// target, and RBAC goes last so maas-api can shut down cleanly. Unlisted kinds use rank 4.
+var deletionOrder = map[string]int{
+	"AuthPolicy":           0,
+	"RateLimitPolicy":      0,
+	"TokenRateLimitPolicy": 0,
+	"HTTPRoute":            1,
+	"Gateway":              2,
+	"GatewayClass":         3,
+	"Kuadrant":             3,
+	"ClusterRoleBinding":   5,
+	"RoleBinding":          5,
+	"ClusterRole":          6,
+	"Role":                 6,
+}
+
+// tierManagedKinds are written by the Tier controller and found by label on cleanup
+var tierManagedKinds = []schema.GroupVersionKind{
+	{Group: "", Version: "v1", Kind: "ConfigMap"},
+	render.RateLimitPolicyGVK,
+	render.TokenRateLimitPolicyGVK,
+}
+
+// recordManagedResource adds an object to the MaasPlatform inventory
+func recordManagedResource(maasPlatform *myappv1alpha1.MaasPlatform, obj *unstructured.Unstructured) {
+	resource := myappv1alpha1.ManagedResource{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+	}
+	for _, existing := range maasPlatform.Status.ManagedResources {
+		if existing == resource {
+			return
+		}
+	}
+	maasPlatform.Status.ManagedResources = append(maasPlatform.Status.ManagedResources, resource)
+}
+
+// forgetManagedResource removes an object from the MaasPlatform inventory
+func forgetManagedResource(maasPlatform *myappv1alpha1.MaasPlatform, obj *unstructured.Unstructured) {
+	resources := maasPlatform.Status.ManagedResources[:0]
+	for _, existing := range maasPlatform.Status.ManagedResources {
+		if existing.Kind == obj.GetKind() && existing.Namespace == obj.GetNamespace() && existing.Name == obj.GetName() {
+			continue
+		}
+		resources = append(resources, existing)
+	}
+	maasPlatform.Status.ManagedResources = resources
+}
+
+// finalize deletes the managed resources of a MaasPlatform in dependency order.
+// It returns false while resources of an earlier stage are still terminating.
+func (r *MaasPlatformReconciler) finalize(ctx context.Context, maasPlatform *myappv1alpha1.MaasPlatform) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	resources, err := r.collectManagedResources(ctx, maasPlatform)
+	if err != nil {
+		return false, err
+	}
+
+	// Other platforms still rely on the shared resources
+	others, err := r.otherPlatformsExist(ctx, maasPlatform)
+	if err != nil {
+		return false, err
+	}
+	if others {
+		owned := resources[:0]
+		for _, obj := range resources {
+			if isSharedResource(obj.GetKind(), obj.GetName()) {
+				log.Info("Keeping shared resource for the remaining platforms", "kind", obj.GetKind(), "name", obj.GetName())
+				continue
+			}
+			owned = append(owned, obj)
+		}
+		resources = owned
+	}
+
+	sort.SliceStable(resources, func(i, j int) bool {
+		return deletionRank(resources[i].GetKind()) < deletionRank(resources[j].GetKind())
+	})
+
+	// Delete one stage at a time, waiting for it to be gone before moving on
+	for start := 0; start < len(resources); {
+		rank := deletionRank(resources[start].GetKind())
+		end := start
+		for end < len(resources) && deletionRank(resources[end].GetKind()) == rank {
+			end++
+		}
+
+		remaining := 0
+		for _, obj := range resources[start:end] {
+			err := r.Delete(ctx, obj)
+			if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				continue
+			} else if err != nil {
+				return false, fmt.Errorf("failed to delete %s %s/%s: %w", obj.GetKind(), obj.GetNamespace(), obj.GetName(), err)
+			}
+			log.Info("Deleted managed resource", "kind", obj.GetKind(), "name", obj.GetName(), "namespace", obj.GetNamespace())
+
+			current := &unstructured.Unstructured{}
+			current.SetGroupVersionKind(obj.GroupVersionKind())
+			if err := r.Get(ctx, client.ObjectKeyFromObject(obj), current); err == nil {
+				remaining++
+			} else if !errors.IsNotFound(err) {
+				return false, fmt.Errorf("failed to check %s %s/%s: %w", obj.GetKind(), obj.GetNamespace(), obj.GetName(), err)
+			}
+		}
+
+		if remaining > 0 {
+			log.Info("Waiting for managed resources to be deleted", "remaining", remaining)
+			return false, nil
+		}
+		start = end
+	}
+
+	return true, nil
+}
+
+// collectManagedResources returns the inventory of the MaasPlatform plus the labelled
+// resources written by the Tier controller
+func (r *MaasPlatformReconciler) collectManagedResources(ctx context.Context, maasPlatform *myappv1alpha1.MaasPlatform) ([]*unstructured.Unstructured, error) {
+	var resources []*unstructured.Unstructured
+	seen := make(map[string]bool)
+
+	add := func(obj *unstructured.Unstructured) {
+		key := fmt.Sprintf("%s/%s/%s/%s", obj.GetAPIVersion(), obj.GetKind(), obj.GetNamespace(), obj.GetName())
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		resources = append(resources, obj)
+	}
+
+	for _, resource := range maasPlatform.Status.ManagedResources {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(resource.APIVersion)
+		obj.SetKind(resource.Kind)
+		obj.SetNamespace(resource.Namespace)
+		obj.SetName(resource.Name)
+		add(obj)
+	}
+
+	for _, gvk := range tierManagedKinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		err := r.List(ctx, list, client.MatchingLabels{render.PlatformLabel: render.PlatformLabelValue(maasPlatform)})
+		if meta.IsNoMatchError(err) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", gvk.Kind, err)
+		}
+		for i := range list.Items {
+			item := &list.Items[i]
+			item.SetGroupVersionKind(gvk)
+			add(item)
+		}
+	}
+
+	return resources, nil
+}
+
+// otherPlatformsExist reports whether a MaasPlatform other than the given one is still around
+func (r *MaasPlatformReconciler) otherPlatformsExist(ctx context.Context, maasPlatform *myappv1alpha1.MaasPlatform) (bool, error) {
+	platformList := &myappv1alpha1.MaasPlatformList{}
+	if err := r.List(ctx, platformList); err != nil {
+		return false, fmt.Errorf("failed to list MaasPlatforms: %w", err)
+	}
+	for _, other := range platformList.Items {
+		if other.UID != maasPlatform.UID && other.DeletionTimestamp.IsZero() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// deletionRank returns the deletion stage of a kind
+func deletionRank(kind string) int {
+	if rank, ok := deletionOrder[kind]; ok {
+		return rank
+	}
+	return 4
+}
@@ -0,0 +1,33 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+	"github.com/jland-redhat/maas-operator.git/pkg/render"
+)
+
+// obsoleteNetworkingObjects returns the networking objects the spec no longer asks for:
+// the HTTP-to-HTTPS redirect route once it is disabled
+func obsoleteNetworkingObjects(maasPlatform *myappv1alpha1.MaasPlatform) []*unstructured.Unstructured {
+	if maasPlatform.Spec.Gateway != nil && maasPlatform.Spec.Gateway.RedirectHTTPToHTTPS {
+		return nil
+	}
+	return []*unstructured.Unstructured{render.HTTPSRedirectRoute(maasPlatform)}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+	"github.com/jland-redhat/maas-operator.git/pkg/render"
+)
+
+// expandVolume grows an existing PVC to the size requested by the rendered one.
+// Everything else is left alone, since most of a PVC spec is immutable.
+func (r *MaasPlatformReconciler) expandVolume(ctx context.Context, existing, desired *unstructured.Unstructured) error {
+	log := logf.FromContext(ctx)
+
+	desiredSize, err := nestedQuantity(desired, "spec", "resources", "requests", "storage")
+	if err != nil || desiredSize == nil {
+		return err
+	}
+	currentSize, err := nestedQuantity(existing, "spec", "resources", "requests", "storage")
+	if err != nil {
+		return err
+	}
+	if currentSize != nil && desiredSize.Cmp(*currentSize) <= 0 {
+		return nil
+	}
+
+	patch := client.MergeFrom(existing.DeepCopy())
+	if err := unstructured.SetNestedField(existing.Object, desiredSize.String(), "spec", "resources", "requests", "storage"); err != nil {
+		return fmt.Errorf("failed to set PVC size: %w", err)
+	}
+	if err := r.Patch(ctx, existing, patch); err != nil {
+		return fmt.Errorf("failed to expand PVC %s/%s: %w", existing.GetNamespace(), existing.GetName(), err)
+	}
+	log.Info("Expanded PersistentVolumeClaim", "name", existing.GetName(), "namespace", existing.GetNamespace(), "size", desiredSize.String())
+	return nil
+}
+
+// nestedQuantity reads a resource quantity from an unstructured object, nil when unset
+func nestedQuantity(obj *unstructured.Unstructured, fields ...string) (*resource.Quantity, error) {
+	value, found, err := unstructured.NestedString(obj.Object, fields...)
+	if err != nil || !found {
+		return nil, err
+	}
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quantity %q: %w", value, err)
+	}
+	return &quantity, nil
+}
+
+// obsoleteMaasAPIObjects returns the maas-api objects the spec no longer asks for: the embedded
+// database PVC once maas-api uses an external database, and the PodDisruptionBudget and
+// HorizontalPodAutoscaler once they are no longer configured
+func obsoleteMaasAPIObjects(maasPlatform *myappv1alpha1.MaasPlatform) []*unstructured.Unstructured {
+	names := render.NamesFor(maasPlatform)
+	config := maasPlatform.Spec.MaasAPI
+	if config == nil {
+		config = &myappv1alpha1.MaasAPIConfig{}
+	}
+
+	var objects []*unstructured.Unstructured
+	if maasPlatform.Spec.UsesExternalDatabase() {
+		objects = append(objects, maasAPIObject(maasPlatform, corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim"), names.MaasAPIDatabase))
+	}
+	if config.PodDisruptionBudget == nil {
+		objects = append(objects, maasAPIObject(maasPlatform, policyv1.SchemeGroupVersion.WithKind("PodDisruptionBudget"), names.MaasAPI))
+	}
+	if config.Autoscaling == nil {
+		objects = append(objects, maasAPIObject(maasPlatform, autoscalingv2.SchemeGroupVersion.WithKind("HorizontalPodAutoscaler"), names.MaasAPI))
+	}
+	return objects
+}
+
+// maasAPIObject returns a reference to a generated object in the maas-api namespace
+func maasAPIObject(maasPlatform *myappv1alpha1.MaasPlatform, gvk schema.GroupVersionKind, name string) *unstructured.Unstructured {
+	obj := newUnstructured(gvk)
+	obj.SetNamespace(maasPlatform.Spec.APINamespace())
+	obj.SetName(name)
+	return obj
+}
@@ -17,45 +17,65 @@ limitations under the License.
 package controller
 
 import (
-	"bytes"
 	"context"
-	"embed"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+	"github.com/jland-redhat/maas-operator.git/pkg/render"
 )
 
-//go:embed manifests
-var manifestsFS embed.FS
-
 // MaasPlatformReconciler reconciles a MaasPlatform object
 type MaasPlatformReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// Discovery checks that the prerequisite APIs are served before deploying
+	Discovery discovery.DiscoveryInterface
+
+	// Recorder publishes the plans of the Plan management state as Events
+	Recorder record.EventRecorder
+	// PlanOnly plans the changes of every MaasPlatform, whatever its management state
+	PlanOnly bool
 }
 
 // +kubebuilder:rbac:groups=myapp.io.odh.maas,resources=maasplatforms,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=myapp.io.odh.maas,resources=maasplatforms/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=myapp.io.odh.maas,resources=maasplatforms/finalizers,verbs=update
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=namespaces;services;configmaps;serviceaccounts;secrets;pods;endpoints;persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=serviceaccounts/token,verbs=create
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles;clusterrolebindings;roles;rolebindings,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways;gatewayclasses;httproutes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=kuadrant.io,resources=kuadrants;authpolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=config.openshift.io,resources=ingresses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=serving.kserve.io,resources=inferenceservices;llminferenceservices,verbs=get;list;watch
 // +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state specified by
@@ -74,123 +94,237 @@ func (r *MaasPlatformReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, err
 	}
 
-	// Create required namespaces
-	log.Info("Ensuring required namespaces exist")
-	if err := r.ensureNamespaces(ctx); err != nil {
-		log.Error(err, "Failed to create required namespaces")
-		return ctrl.Result{}, err
+	// Clean up the managed resources before letting the MaasPlatform go
+	if !maasPlatform.DeletionTimestamp.IsZero() {
+		if !controllerutil.ContainsFinalizer(maasPlatform, maasPlatformFinalizer) {
+			return ctrl.Result{}, nil
+		}
+
+		if maasPlatform.Spec.DeletionPolicy == myappv1alpha1.DeletionPolicyRetain {
+			log.Info("Retaining managed resources as requested by the deletion policy")
+		} else if message := deletionBlocked(r.PlanOnly, maasPlatform); message != "" {
+			// Letting the platform go would leave its cluster-scoped and cross-namespace resources behind
+			return r.blockDeletion(ctx, maasPlatform, message)
+		} else {
+			log.Info("Deleting managed resources")
+			done, err := r.finalize(ctx, maasPlatform)
+			if err != nil {
+				log.Error(err, "Failed to delete managed resources")
+				return ctrl.Result{}, err
+			}
+			if !done {
+				return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+			}
+		}
+
+		controllerutil.RemoveFinalizer(maasPlatform, maasPlatformFinalizer)
+		if err := r.Update(ctx, maasPlatform); err != nil {
+			log.Error(err, "Failed to remove finalizer")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
 	}
 
-	// Deploy maas-api resources (excluding ConfigMap which will be managed by Tier)
-	log.Info("Deploying maas-api resources")
-	if err := r.deployEmbeddedManifest(ctx, "manifests/maas-api/resources.yaml", maasPlatform, true); err != nil {
-		log.Error(err, "Failed to deploy maas-api resources")
-		return ctrl.Result{}, err
+	if !controllerutil.ContainsFinalizer(maasPlatform, maasPlatformFinalizer) {
+		// A platform deployed by an earlier release keeps the objects it has rather than getting
+		// renamed copies next to them
+		legacy, err := r.deployedWithLegacyNames(ctx, maasPlatform)
+		if err != nil {
+			log.Error(err, "Failed to check for legacy objects")
+			return ctrl.Result{}, err
+		}
+		if legacy {
+			log.Info("Keeping the object names of a platform deployed by an earlier release")
+			metav1.SetMetaDataAnnotation(&maasPlatform.ObjectMeta, myappv1alpha1.NamingAnnotation, myappv1alpha1.NamingLegacy)
+		}
+		controllerutil.AddFinalizer(maasPlatform, maasPlatformFinalizer)
+		if err := r.Update(ctx, maasPlatform); err != nil {
+			log.Error(err, "Failed to add finalizer")
+			return ctrl.Result{}, err
+		}
 	}
 
-	// Deploy networking resources
-	log.Info("Deploying networking resources")
-	if err := r.deployEmbeddedManifest(ctx, "manifests/networking/resources.yaml", maasPlatform, false); err != nil {
-		log.Error(err, "Failed to deploy networking resources")
-		return ctrl.Result{}, err
+	// Suspended platforms are left alone until they are managed again
+	if reason, message := suspension(maasPlatform); reason != "" {
+		return r.suspend(ctx, maasPlatform, reason, message)
+	}
+	if maasPlatform.Spec.ManagementState == myappv1alpha1.ManagementStateRemoved && !planOnly(r.PlanOnly, maasPlatform) {
+		return r.removePlatform(ctx, maasPlatform)
 	}
 
-	// Deploy gateway-auth-policy
-	log.Info("Deploying gateway-auth-policy")
-	if err := r.deployEmbeddedManifest(ctx, "manifests/policies/gateway-auth-policy.yaml", maasPlatform, false); err != nil {
-		log.Error(err, "Failed to deploy gateway-auth-policy")
-		return ctrl.Result{}, err
+	// A spec that cannot be rendered won't get better by retrying
+	if err := maasPlatform.Spec.Validate(); err != nil {
+		log.Info("Invalid MaasPlatform spec", "reason", err.Error())
+		return r.updateStatus(ctx, maasPlatform, reconcile.TerminalError(err))
 	}
 
-	// Update status
-	if err := r.updateStatus(ctx, maasPlatform); err != nil {
-		log.Error(err, "Failed to update status")
-		return ctrl.Result{}, err
+	// Applying kinds the cluster does not serve fails on every object, check up front instead
+	if err := r.checkPrerequisites(maasPlatform); err != nil {
+		log.Info("Prerequisites missing, waiting for them to be installed", "reason", err.Error())
+		return r.updateStatus(ctx, maasPlatform, err)
 	}
 
-	return ctrl.Result{}, nil
-}
+	clusterDomain := r.detectClusterDomain(ctx, maasPlatform)
+	maasPlatform.Status.ClusterDomain = clusterDomain
+	// Conflicts are collected again on every apply
+	maasPlatform.Status.Conflicts = nil
 
-// ensureNamespaces creates required namespaces if they don't exist
-func (r *MaasPlatformReconciler) ensureNamespaces(ctx context.Context) error {
-	requiredNamespaces := []string{
-		"maas-api",
-		"kuadrant-system",
-		// Add more namespaces here if needed
+	// Render the whole platform up front, the steps below only apply it
+	desired, err := render.RenderPlatform(maasPlatform, render.ClusterFacts{Domain: clusterDomain})
+	maasPlatform.Status.Patches = desired.Patches
+	if err != nil {
+		log.Error(err, "Failed to render MaasPlatform")
+		return r.updateStatus(ctx, maasPlatform, err)
+	}
+	for _, component := range desired.UnusedComponents {
+		setOptionalComponentStatus(maasPlatform, component, myappv1alpha1.ComponentModeDisabled, myappv1alpha1.ComponentPhaseReady,
+			fmt.Sprintf("Not used by the %s profile", maasPlatform.Spec.Profile))
 	}
 
-	for _, ns := range requiredNamespaces {
-		namespace := &unstructured.Unstructured{
-			Object: map[string]interface{}{
-				"apiVersion": "v1",
-				"kind":       "Namespace",
-				"metadata": map[string]interface{}{
-					"name": ns,
-				},
-			},
-		}
+	// Compare the desired state with the cluster without writing anything
+	if planOnly(r.PlanOnly, maasPlatform) {
+		return r.planPlatform(ctx, maasPlatform, desired)
+	}
 
-		err := r.Get(ctx, client.ObjectKey{Name: ns}, namespace)
-		if errors.IsNotFound(err) {
-			// Create the namespace
-			if err := r.Create(ctx, namespace); err != nil {
-				return fmt.Errorf("failed to create namespace %s: %w", ns, err)
-			}
-			logf.FromContext(ctx).Info("Created namespace", "namespace", ns)
-		} else if err != nil {
-			return fmt.Errorf("failed to check namespace %s: %w", ns, err)
-		}
+	// The platform is deployed in phases, each one waits for what the next one builds on:
+	// namespaces, Kuadrant ready, gateway programmed, maas-api available, then the policies
+	log.Info("Ensuring required namespaces exist")
+	if err := r.ensureNamespaces(ctx, desired.Namespaces); err != nil {
+		log.Error(err, "Failed to create required namespaces")
+		return r.updatePhaseStatus(ctx, maasPlatform, myappv1alpha1.DeploymentPhaseNamespaces, err)
 	}
 
-	return nil
-}
+	// Kuadrant and the gateways, the gateway policies need Kuadrant to be ready to be enforced
+	if skip, err := r.skipComponent(ctx, maasPlatform, myappv1alpha1.ComponentNetworking,
+		desired.Kuadrant, desired.Networking, obsoleteNetworkingObjects(maasPlatform)); err != nil {
+		log.Error(err, "Failed to remove networking resources")
+		return r.updatePhaseStatus(ctx, maasPlatform, myappv1alpha1.DeploymentPhaseGateway, err)
+	} else if !skip {
+		log.Info("Deploying Kuadrant")
+		if err := r.applyObjects(ctx, desired.Kuadrant, maasPlatform); err != nil {
+			log.Error(err, "Failed to deploy Kuadrant")
+			setComponentStatus(maasPlatform, myappv1alpha1.ComponentNetworking, myappv1alpha1.ComponentPhaseFailed, err.Error())
+			return r.updatePhaseStatus(ctx, maasPlatform, myappv1alpha1.DeploymentPhaseKuadrant, err)
+		}
+		if err := r.checkKuadrantReady(ctx, maasPlatform, desired.Kuadrant); err != nil {
+			log.Info("Waiting for Kuadrant", "reason", err.Error())
+			return r.updatePhaseStatus(ctx, maasPlatform, myappv1alpha1.DeploymentPhaseKuadrant, err)
+		}
 
-// deployEmbeddedManifest deploys a manifest from the embedded filesystem
-func (r *MaasPlatformReconciler) deployEmbeddedManifest(ctx context.Context, path string, maasPlatform *myappv1alpha1.MaasPlatform, skipConfigMap bool) error {
-	log := logf.FromContext(ctx)
+		log.Info("Deploying networking resources")
+		if err := r.applyObjects(ctx, desired.Networking, maasPlatform); err != nil {
+			log.Error(err, "Failed to deploy networking resources")
+			setComponentStatus(maasPlatform, myappv1alpha1.ComponentNetworking, myappv1alpha1.ComponentPhaseFailed, err.Error())
+			return r.updatePhaseStatus(ctx, maasPlatform, myappv1alpha1.DeploymentPhaseGateway, err)
+		}
 
-	data, err := manifestsFS.ReadFile(path)
-	if err != nil {
-		return fmt.Errorf("failed to read embedded manifest %s: %w", path, err)
+		// Remove the HTTP-to-HTTPS redirect route once it is disabled
+		if err := r.deleteObsolete(ctx, obsoleteNetworkingObjects(maasPlatform), maasPlatform); err != nil {
+			log.Error(err, "Failed to remove obsolete networking resources")
+			setComponentStatus(maasPlatform, myappv1alpha1.ComponentNetworking, myappv1alpha1.ComponentPhaseFailed, err.Error())
+			return r.updatePhaseStatus(ctx, maasPlatform, myappv1alpha1.DeploymentPhaseGateway, err)
+		}
+		if err := r.checkGatewayProgrammed(ctx, maasPlatform, desired.Networking); err != nil {
+			log.Info("Waiting for the gateway", "reason", err.Error())
+			return r.updatePhaseStatus(ctx, maasPlatform, myappv1alpha1.DeploymentPhaseGateway, err)
+		}
 	}
 
-	// Substitute environment variables
-	dataStr := string(data)
-	dataStr = r.substituteEnvVars(ctx, dataStr)
-	data = []byte(dataStr)
-
-	// Parse YAML (support multi-document YAML)
-	documents, err := splitYAMLDocuments(data)
+	// Deploy maas-api resources (the tier ConfigMap is managed by the Tier controller),
+	// its AuthPolicy waits for the policies phase
+	maasAPISkipped, err := r.skipComponent(ctx, maasPlatform, myappv1alpha1.ComponentMaasAPI,
+		desired.MaasAPI, desired.MaasAPIPolicies, obsoleteMaasAPIObjects(maasPlatform))
 	if err != nil {
-		return fmt.Errorf("failed to parse YAML: %w", err)
+		log.Error(err, "Failed to remove maas-api resources")
+		return r.updatePhaseStatus(ctx, maasPlatform, myappv1alpha1.DeploymentPhaseMaasAPI, err)
+	} else if !maasAPISkipped {
+		log.Info("Deploying maas-api resources")
+		if err := r.applyObjects(ctx, desired.MaasAPI, maasPlatform); err != nil {
+			log.Error(err, "Failed to deploy maas-api resources")
+			setComponentStatus(maasPlatform, myappv1alpha1.ComponentMaasAPI, myappv1alpha1.ComponentPhaseFailed, err.Error())
+			return r.updatePhaseStatus(ctx, maasPlatform, myappv1alpha1.DeploymentPhaseMaasAPI, err)
+		}
+		if err := r.deleteObsolete(ctx, obsoleteMaasAPIObjects(maasPlatform), maasPlatform); err != nil {
+			log.Error(err, "Failed to remove obsolete maas-api resources")
+			setComponentStatus(maasPlatform, myappv1alpha1.ComponentMaasAPI, myappv1alpha1.ComponentPhaseFailed, err.Error())
+			return r.updatePhaseStatus(ctx, maasPlatform, myappv1alpha1.DeploymentPhaseMaasAPI, err)
+		}
+		if err := r.checkMaasAPIAvailable(ctx, maasPlatform); err != nil {
+			log.Info("Waiting for maas-api", "reason", err.Error())
+			return r.updatePhaseStatus(ctx, maasPlatform, myappv1alpha1.DeploymentPhaseMaasAPI, err)
+		}
+	}
+
+	// Policies, the Tier controller writes the tier policies from this phase on
+	if !maasAPISkipped {
+		log.Info("Deploying maas-api policies")
+		if err := r.applyObjects(ctx, desired.MaasAPIPolicies, maasPlatform); err != nil {
+			log.Error(err, "Failed to deploy maas-api policies")
+			setComponentStatus(maasPlatform, myappv1alpha1.ComponentMaasAPI, myappv1alpha1.ComponentPhaseFailed, err.Error())
+			return r.updatePhaseStatus(ctx, maasPlatform, myappv1alpha1.DeploymentPhasePolicies, err)
+		}
 	}
 
-	for _, doc := range documents {
-		if len(doc) == 0 {
-			continue
+	// Deploy gateway-auth-policy
+	if skip, err := r.skipComponent(ctx, maasPlatform, myappv1alpha1.ComponentGatewayAuthPolicy, desired.GatewayAuthPolicy); err != nil {
+		log.Error(err, "Failed to remove gateway-auth-policy")
+		return r.updatePhaseStatus(ctx, maasPlatform, myappv1alpha1.DeploymentPhasePolicies, err)
+	} else if !skip {
+		log.Info("Deploying gateway-auth-policy")
+		if err := r.applyObjects(ctx, desired.GatewayAuthPolicy, maasPlatform); err != nil {
+			log.Error(err, "Failed to deploy gateway-auth-policy")
+			setComponentStatus(maasPlatform, myappv1alpha1.ComponentGatewayAuthPolicy, myappv1alpha1.ComponentPhaseFailed, err.Error())
+			return r.updatePhaseStatus(ctx, maasPlatform, myappv1alpha1.DeploymentPhasePolicies, err)
 		}
+		setComponentStatus(maasPlatform, myappv1alpha1.ComponentGatewayAuthPolicy, myappv1alpha1.ComponentPhaseReady, "Resources applied")
+	}
 
-		var obj unstructured.Unstructured
-		if err := yaml.Unmarshal(doc, &obj.Object); err != nil {
-			log.Info("Skipping non-Kubernetes resource (failed to unmarshal)", "file", path)
-			continue
+	// Update status
+	return r.updatePhaseStatus(ctx, maasPlatform, myappv1alpha1.DeploymentPhaseComplete, nil)
+}
+
+// ensureNamespaces creates the rendered namespaces if they don't exist
+func (r *MaasPlatformReconciler) ensureNamespaces(ctx context.Context, namespaces []*unstructured.Unstructured) error {
+	for _, ns := range namespaces {
+		// Namespaces only carry our labels and patches, there is nothing to leave to other managers
+		if err := applyObject(ctx, r.Client, ns, true); err != nil {
+			return fmt.Errorf("failed to apply namespace %s: %w", ns.GetName(), err)
 		}
+	}
+
+	return nil
+}
 
-		// Skip ConfigMap if requested
-		if skipConfigMap && obj.GetKind() == "ConfigMap" && obj.GetName() == "tier-to-group-mapping" {
-			log.Info("Skipping tier-to-group-mapping ConfigMap (managed by Tier controller)")
-			continue
+// applyObjects applies rendered objects of the platform
+func (r *MaasPlatformReconciler) applyObjects(ctx context.Context, objects []*unstructured.Unstructured, maasPlatform *myappv1alpha1.MaasPlatform) error {
+	log := logf.FromContext(ctx)
+
+	for _, obj := range objects {
+		// Optional components may be adopted from an existing installation or disabled
+		if component, ok := render.OptionalComponent(obj); ok {
+			skip, err := r.reconcileOptionalComponent(ctx, obj, maasPlatform, component)
+			if err != nil {
+				return err
+			}
+			if skip {
+				log.Info("Skipping optional component", "component", component, "mode", maasPlatform.Spec.ComponentMode(component))
+				continue
+			}
 		}
 
-		// For PVCs, check if they already exist and skip update (PVCs are immutable)
+		// For PVCs, check if they already exist and only expand them (PVCs are mostly immutable)
 		if obj.GetKind() == "PersistentVolumeClaim" {
 			existing := &unstructured.Unstructured{}
 			existing.SetGroupVersionKind(obj.GroupVersionKind())
 			err := r.Get(ctx, client.ObjectKey{Name: obj.GetName(), Namespace: obj.GetNamespace()}, existing)
 			if err == nil {
-				log.Info("PersistentVolumeClaim already exists, skipping update (PVCs are immutable)",
+				recordManagedResource(maasPlatform, obj)
+				log.Info("PersistentVolumeClaim already exists, only expanding it (PVCs are mostly immutable)",
 					"name", obj.GetName(),
 					"namespace", obj.GetNamespace())
+				if err := r.expandVolume(ctx, existing, obj); err != nil {
+					return err
+				}
 				continue
 			} else if !errors.IsNotFound(err) {
 				return fmt.Errorf("failed to check existing PVC %s/%s: %w", obj.GetNamespace(), obj.GetName(), err)
@@ -198,24 +332,13 @@ func (r *MaasPlatformReconciler) deployEmbeddedManifest(ctx context.Context, pat
 			// If not found, continue to create it
 		}
 
-		// Set owner reference (skip for cluster-scoped resources and cross-namespace resources)
-		// Owner references cannot span namespaces
-		if obj.GetNamespace() != "" && obj.GetNamespace() == maasPlatform.Namespace {
-			if err := ctrl.SetControllerReference(maasPlatform, &obj, r.Scheme); err != nil {
-				log.Info("Failed to set owner reference, continuing anyway", "error", err)
-			}
-		} else if obj.GetNamespace() != "" {
-			log.V(1).Info("Skipping owner reference for cross-namespace resource",
-				"kind", obj.GetKind(),
-				"name", obj.GetName(),
-				"namespace", obj.GetNamespace(),
-				"owner-namespace", maasPlatform.Namespace)
-		}
+		r.setOwnerReference(ctx, obj, maasPlatform)
 
 		// Apply the resource
-		if err := r.applyUnstructured(ctx, &obj); err != nil {
+		if err := r.applyUnstructured(ctx, obj, maasPlatform); err != nil {
 			return fmt.Errorf("failed to apply resource %s/%s: %w", obj.GetKind(), obj.GetName(), err)
 		}
+		recordManagedResource(maasPlatform, obj)
 
 		log.Info("Successfully deployed resource", "kind", obj.GetKind(), "name", obj.GetName(), "namespace", obj.GetNamespace())
 	}
@@ -223,13 +346,46 @@ func (r *MaasPlatformReconciler) deployEmbeddedManifest(ctx context.Context, pat
 	return nil
 }
 
-// substituteEnvVars replaces ${VAR} style variables in the manifest
-func (r *MaasPlatformReconciler) substituteEnvVars(ctx context.Context, content string) string {
+// setOwnerReference makes the MaasPlatform the controller of an object in its namespace.
+// Cluster-scoped and cross-namespace resources are skipped, owner references cannot span namespaces.
+func (r *MaasPlatformReconciler) setOwnerReference(ctx context.Context, obj *unstructured.Unstructured, maasPlatform *myappv1alpha1.MaasPlatform) {
 	log := logf.FromContext(ctx)
 
-	// Get CLUSTER_DOMAIN from cluster if not set
+	if obj.GetNamespace() != "" && obj.GetNamespace() == maasPlatform.Namespace {
+		if err := ctrl.SetControllerReference(maasPlatform, obj, r.Scheme); err != nil {
+			log.Info("Failed to set owner reference, continuing anyway", "error", err)
+		}
+	} else if obj.GetNamespace() != "" {
+		log.V(1).Info("Skipping owner reference for cross-namespace resource",
+			"kind", obj.GetKind(),
+			"name", obj.GetName(),
+			"namespace", obj.GetNamespace(),
+			"owner-namespace", maasPlatform.Namespace)
+	}
+}
+
+// deleteObsolete deletes objects the spec no longer asks for and drops them from the inventory
+func (r *MaasPlatformReconciler) deleteObsolete(ctx context.Context, objects []*unstructured.Unstructured, maasPlatform *myappv1alpha1.MaasPlatform) error {
+	for _, obj := range objects {
+		if err := r.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete %s %s: %w", obj.GetKind(), objectKeyString(obj), err)
+		}
+		forgetManagedResource(maasPlatform, obj)
+	}
+	return nil
+}
+
+// detectClusterDomain returns the cluster domain from the spec, CLUSTER_DOMAIN or the OpenShift ingress config
+func (r *MaasPlatformReconciler) detectClusterDomain(ctx context.Context, maasPlatform *myappv1alpha1.MaasPlatform) string {
+	log := logf.FromContext(ctx)
+
+	if maasPlatform.Spec.ClusterDomain != "" {
+		return maasPlatform.Spec.ClusterDomain
+	}
+
+	// Get CLUSTER_DOMAIN from cluster if not set, the ingress config only exists on OpenShift
 	clusterDomain := os.Getenv("CLUSTER_DOMAIN")
-	if clusterDomain == "" {
+	if clusterDomain == "" && maasPlatform.Spec.IsOpenShift() {
 		// Try to detect from cluster ingress
 		var ingressConfig unstructured.Unstructured
 		ingressConfig.SetAPIVersion("config.openshift.io/v1")
@@ -243,92 +399,202 @@ func (r *MaasPlatformReconciler) substituteEnvVars(ctx context.Context, content
 		}
 
 		if clusterDomain == "" {
-			clusterDomain = "apps.example.com"
+			clusterDomain = render.DefaultClusterDomain
 			log.Info("Using default cluster domain", "domain", clusterDomain)
 		}
 	}
 
-	// Replace variables
-	content = strings.ReplaceAll(content, "${CLUSTER_DOMAIN}", clusterDomain)
-	content = strings.ReplaceAll(content, "$CLUSTER_DOMAIN", clusterDomain)
-
-	return content
+	return clusterDomain
 }
 
 // deployResourcesFromPath is kept for backwards compatibility but no longer used
 func (r *MaasPlatformReconciler) deployResourcesFromPath(ctx context.Context, path string, maasPlatform *myappv1alpha1.MaasPlatform, skipConfigMap bool) error {
-	return fmt.Errorf("deprecated: use deployEmbeddedManifest instead")
+	return fmt.Errorf("deprecated: use applyObjects instead")
 }
 
 // deployKustomizeDirectory is kept for backwards compatibility but no longer used
 func (r *MaasPlatformReconciler) deployKustomizeDirectory(ctx context.Context, path string, maasPlatform *myappv1alpha1.MaasPlatform, skipConfigMap bool) error {
-	return fmt.Errorf("deprecated: use deployEmbeddedManifest instead")
+	return fmt.Errorf("deprecated: use applyObjects instead")
 }
 
 // deployDirectoryYAMLs is kept for backwards compatibility but no longer used
 func (r *MaasPlatformReconciler) deployDirectoryYAMLs(ctx context.Context, dir string, maasPlatform *myappv1alpha1.MaasPlatform, skipConfigMap bool) error {
-	return fmt.Errorf("deprecated: use deployEmbeddedManifest instead")
+	return fmt.Errorf("deprecated: use applyObjects instead")
 }
 
 // deploySingleResource is kept for backwards compatibility but no longer used
 func (r *MaasPlatformReconciler) deploySingleResource(ctx context.Context, filePath string, maasPlatform *myappv1alpha1.MaasPlatform, skipConfigMap ...bool) error {
-	return fmt.Errorf("deprecated: use deployEmbeddedManifest instead")
+	return fmt.Errorf("deprecated: use applyObjects instead")
 }
 
-// applyUnstructured applies an unstructured resource using server-side apply
-func (r *MaasPlatformReconciler) applyUnstructured(ctx context.Context, obj *unstructured.Unstructured) error {
-	current := &unstructured.Unstructured{}
-	current.SetGroupVersionKind(obj.GroupVersionKind())
+// applyUnstructured applies an unstructured resource using server-side apply.
+// With the Report conflict policy, a conflict rejects the whole apply: the resource is left as it is
+// and listed in the status instead of failing the reconcile.
+func (r *MaasPlatformReconciler) applyUnstructured(ctx context.Context, obj *unstructured.Unstructured, maasPlatform *myappv1alpha1.MaasPlatform) error {
+	err := applyObject(ctx, r.Client, obj, forceConflicts(maasPlatform))
+	if errors.IsConflict(err) {
+		logf.FromContext(ctx).Info("Field conflict, leaving the resource as it is",
+			"kind", obj.GetKind(), "name", obj.GetName(), "namespace", obj.GetNamespace(), "conflict", err.Error())
+		recordApplyConflict(maasPlatform, obj, err)
+		return nil
+	}
+	return err
+}
 
-	key := client.ObjectKeyFromObject(obj)
-	err := r.Get(ctx, key, current)
+// checkMaasAPIAvailable holds the maas-api phase until the maas-api Deployment has become available
+func (r *MaasPlatformReconciler) checkMaasAPIAvailable(ctx context.Context, maasPlatform *myappv1alpha1.MaasPlatform) error {
+	deployment := &appsv1.Deployment{}
+	name := render.NamesFor(maasPlatform).MaasAPI
+	err := r.Get(ctx, client.ObjectKey{Name: name, Namespace: maasPlatform.Spec.APINamespace()}, deployment)
 	if errors.IsNotFound(err) {
-		// Create new resource
-		return r.Create(ctx, obj)
+		message := fmt.Sprintf("Waiting for Deployment %s to be created", name)
+		setComponentStatus(maasPlatform, myappv1alpha1.ComponentMaasAPI, myappv1alpha1.ComponentPhaseProgressing, message)
+		return &phaseNotReadyError{phase: myappv1alpha1.DeploymentPhaseMaasAPI, message: message}
 	} else if err != nil {
-		return err
+		return fmt.Errorf("failed to get maas-api Deployment: %w", err)
 	}
 
-	// Update existing resource - use server-side apply semantics
-	obj.SetResourceVersion(current.GetResourceVersion())
-	return r.Update(ctx, obj)
-}
+	for _, cond := range deployment.Status.Conditions {
+		if cond.Type == appsv1.DeploymentAvailable && cond.Status == corev1.ConditionTrue {
+			setComponentStatus(maasPlatform, myappv1alpha1.ComponentMaasAPI, myappv1alpha1.ComponentPhaseReady, fmt.Sprintf("Deployment %s is available", name))
+			return nil
+		}
+	}
 
-// updateStatus updates the MaasPlatform status
-func (r *MaasPlatformReconciler) updateStatus(ctx context.Context, maasPlatform *myappv1alpha1.MaasPlatform) error {
-	// Status updates can be added here when status fields are defined
-	// For now, just update the status subresource
-	return r.Status().Update(ctx, maasPlatform)
+	message := fmt.Sprintf("Waiting for Deployment %s to become available", name)
+	setComponentStatus(maasPlatform, myappv1alpha1.ComponentMaasAPI, myappv1alpha1.ComponentPhaseProgressing, message)
+	return &phaseNotReadyError{phase: myappv1alpha1.DeploymentPhaseMaasAPI, message: message}
 }
 
-// splitYAMLDocuments splits multi-document YAML into individual documents
-func splitYAMLDocuments(data []byte) ([][]byte, error) {
-	var documents [][]byte
-	var currentDoc []byte
-
-	lines := bytes.Split(data, []byte("\n"))
-	for i, line := range lines {
-		if bytes.HasPrefix(bytes.TrimSpace(line), []byte("---")) && len(currentDoc) > 0 {
-			documents = append(documents, currentDoc)
-			currentDoc = nil
-			continue
+// setComponentStatus records the phase of a platform component in the status
+func setComponentStatus(maasPlatform *myappv1alpha1.MaasPlatform, name, phase, message string) {
+	component := myappv1alpha1.ComponentStatus{Name: name, Phase: phase, Message: message}
+	for i := range maasPlatform.Status.Components {
+		if maasPlatform.Status.Components[i].Name == name {
+			maasPlatform.Status.Components[i] = component
+			return
 		}
-		currentDoc = append(currentDoc, line...)
-		if i < len(lines)-1 {
-			currentDoc = append(currentDoc, '\n')
+	}
+	maasPlatform.Status.Components = append(maasPlatform.Status.Components, component)
+}
+
+// updateStatus derives the MaasPlatform conditions from the reconcile outcome and writes the status.
+// The reconcile error is returned unchanged so callers can return the result directly.
+func (r *MaasPlatformReconciler) updateStatus(ctx context.Context, maasPlatform *myappv1alpha1.MaasPlatform, reconcileErr error) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	result := ctrl.Result{}
+
+	var progressing []string
+	for _, component := range maasPlatform.Status.Components {
+		if component.Phase == myappv1alpha1.ComponentPhaseProgressing {
+			progressing = append(progressing, component.Name)
 		}
 	}
-	if len(currentDoc) > 0 {
-		documents = append(documents, currentDoc)
+
+	missingErr, prerequisitesMissing := reconcileErr.(*prerequisitesMissingError)
+	notReadyErr, phaseNotReady := reconcileErr.(*phaseNotReadyError)
+	switch {
+	case prerequisitesMissing:
+		setCondition(maasPlatform, myappv1alpha1.ConditionReady, metav1.ConditionFalse, "PrerequisitesMissing", missingErr.Error())
+		setCondition(maasPlatform, myappv1alpha1.ConditionProgressing, metav1.ConditionFalse, "PrerequisitesMissing", missingErr.Error())
+		setCondition(maasPlatform, myappv1alpha1.ConditionDegraded, metav1.ConditionTrue, "PrerequisitesMissing", missingErr.Error())
+		// Nothing to retry until the CRDs are installed, which the CRD watch picks up
+		result.RequeueAfter = prerequisitesRequeueInterval
+		reconcileErr = nil
+	case phaseNotReady:
+		reason := "WaitingFor" + notReadyErr.phase
+		message := fmt.Sprintf("Blocked in the %s phase: %s", notReadyErr.phase, notReadyErr.message)
+		setCondition(maasPlatform, myappv1alpha1.ConditionReady, metav1.ConditionFalse, reason, message)
+		setCondition(maasPlatform, myappv1alpha1.ConditionProgressing, metav1.ConditionTrue, reason, message)
+		setCondition(maasPlatform, myappv1alpha1.ConditionDegraded, metav1.ConditionFalse, reason, message)
+		// The watches usually get there first, the backoff covers resources that are not watched
+		result.RequeueAfter = phaseBackoff(maasPlatform)
+		reconcileErr = nil
+	case reconcileErr != nil:
+		setCondition(maasPlatform, myappv1alpha1.ConditionReady, metav1.ConditionFalse, "ReconcileFailed", reconcileErr.Error())
+		setCondition(maasPlatform, myappv1alpha1.ConditionProgressing, metav1.ConditionFalse, "ReconcileFailed", reconcileErr.Error())
+		setCondition(maasPlatform, myappv1alpha1.ConditionDegraded, metav1.ConditionTrue, "ReconcileFailed", reconcileErr.Error())
+	case len(progressing) > 0:
+		message := fmt.Sprintf("Waiting for components: %s", strings.Join(progressing, ", "))
+		setCondition(maasPlatform, myappv1alpha1.ConditionReady, metav1.ConditionFalse, "ComponentsProgressing", message)
+		setCondition(maasPlatform, myappv1alpha1.ConditionProgressing, metav1.ConditionTrue, "ComponentsProgressing", message)
+		setCondition(maasPlatform, myappv1alpha1.ConditionDegraded, metav1.ConditionFalse, "ComponentsProgressing", message)
+		// The Deployment watch usually gets there first, this is a safety net until the components are ready
+		result.RequeueAfter = 15 * time.Second
+	default:
+		setCondition(maasPlatform, myappv1alpha1.ConditionReady, metav1.ConditionTrue, "Reconciled", "All components are ready")
+		setCondition(maasPlatform, myappv1alpha1.ConditionProgressing, metav1.ConditionFalse, "Reconciled", "All components are ready")
+		setCondition(maasPlatform, myappv1alpha1.ConditionDegraded, metav1.ConditionFalse, "Reconciled", "All components are ready")
+	}
+
+	// Conflicting resources are not updated at all, which is worth flagging
+	if reconcileErr == nil && len(maasPlatform.Status.Conflicts) > 0 {
+		message := fmt.Sprintf("%d resources were not updated because another field manager owns some of their fields, see status.conflicts",
+			len(maasPlatform.Status.Conflicts))
+		setCondition(maasPlatform, myappv1alpha1.ConditionDegraded, metav1.ConditionTrue, "ApplyConflict", message)
+	}
+	maasPlatform.Status.ObservedGeneration = maasPlatform.Generation
+
+	setSuspendedCondition(maasPlatform)
+
+	// A plan only describes the Plan management state
+	if !planOnly(r.PlanOnly, maasPlatform) {
+		maasPlatform.Status.Plan = nil
+		meta.RemoveStatusCondition(&maasPlatform.Status.Conditions, myappv1alpha1.ConditionChangesPending)
 	}
 
-	return documents, nil
+	if err := r.Status().Update(ctx, maasPlatform); err != nil {
+		log.Error(err, "Failed to update status")
+		if reconcileErr == nil {
+			reconcileErr = err
+		}
+	}
+
+	return result, reconcileErr
+}
+
+// setCondition sets a condition on the MaasPlatform status for the current generation
+func setCondition(maasPlatform *myappv1alpha1.MaasPlatform, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&maasPlatform.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: maasPlatform.Generation,
+	})
 }
 
 // SetupWithManager sets up the controller with the Manager.
+// Managed resources live outside the MaasPlatform namespace, so they are mapped back
+// through the platform label rather than owner references.
 func (r *MaasPlatformReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&myappv1alpha1.MaasPlatform{}).
-		Named("maasplatform").
-		Complete(r)
+		Named("maasplatform")
+
+	w := newManagedWatches(mgr)
+	b = w.watchManaged(b, &appsv1.Deployment{}, platformRequest)
+	b = w.watchManaged(b, &corev1.Service{}, platformRequest)
+	b = w.watchManaged(b, &policyv1.PodDisruptionBudget{}, platformRequest)
+	b = w.watchManaged(b, &autoscalingv2.HorizontalPodAutoscaler{}, platformRequest)
+	b = w.watchManaged(b, newUnstructured(httpRouteGVK), platformRequest)
+	b = w.watchManaged(b, newUnstructured(gatewayGVK), platformRequest)
+	b = w.watchManaged(b, newUnstructured(authPolicyGVK), platformRequest)
+	b = w.watchManaged(b, newUnstructured(kuadrantGVK), platformRequest)
+
+	// Platforms waiting for prerequisites resume once their CRDs are installed
+	crd := &metav1.PartialObjectMetadata{}
+	crd.SetGroupVersionKind(crdGVK)
+	b = b.WatchesMetadata(crd, handler.EnqueueRequestsFromMapFunc(r.platformsForCRD), builder.WithPredicates(prerequisiteCRD))
+
+	return w.complete(b, r)
+}
+
+// platformRequest maps a managed resource to its MaasPlatform
+func platformRequest(_ context.Context, obj client.Object) []reconcile.Request {
+	platform, ok := platformForObject(obj)
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: platform}}
 }
@@ -18,16 +18,33 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	clienttesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+	"github.com/jland-redhat/maas-operator.git/pkg/render"
 )
 
 var _ = Describe("MaasPlatform Controller", func() {
@@ -65,6 +82,17 @@ var _ = Describe("MaasPlatform Controller", func() {
 
 			By("Cleanup the specific resource instance MaasPlatform")
 			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			By("Reconciling the deletion to release the finalizer")
+			controllerReconciler := &MaasPlatformReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, typeNamespacedName, resource))).To(BeTrue())
 		})
 		It("should successfully reconcile the resource", func() {
 			By("Reconciling the created resource")
@@ -80,5 +108,629 @@ var _ = Describe("MaasPlatform Controller", func() {
 			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
 			// Example: If you expect a certain status condition after reconciliation, verify it here.
 		})
+
+		It("should delete the inventoried resources when finalizing", func() {
+			controllerReconciler := &MaasPlatformReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "maas-cleanup-test", Namespace: "default"},
+			}
+			Expect(k8sClient.Create(ctx, configMap)).To(Succeed())
+
+			resource := &myappv1alpha1.MaasPlatform{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			resource.Status.ManagedResources = []myappv1alpha1.ManagedResource{
+				{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "maas-cleanup-test"},
+			}
+
+			done, err := controllerReconciler.finalize(ctx, resource)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(done).To(BeTrue())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(configMap), configMap))).To(BeTrue())
+		})
+
+		It("should report apply conflicts with the Report conflict policy", func() {
+			controllerReconciler := &MaasPlatformReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			newConfigMap := func(value string) *unstructured.Unstructured {
+				obj := &unstructured.Unstructured{}
+				obj.SetAPIVersion("v1")
+				obj.SetKind("ConfigMap")
+				obj.SetName("maas-conflict-test")
+				obj.SetNamespace("default")
+				Expect(unstructured.SetNestedField(obj.Object, value, "data", "key")).To(Succeed())
+				return obj
+			}
+
+			By("Letting another field manager own the data")
+			Expect(k8sClient.Patch(ctx, newConfigMap("theirs"), client.Apply, client.FieldOwner("someone-else"))).To(Succeed())
+
+			resource := &myappv1alpha1.MaasPlatform{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+
+			By("Reporting the conflict instead of failing")
+			resource.Spec.ConflictPolicy = myappv1alpha1.ConflictPolicyReport
+			Expect(controllerReconciler.applyUnstructured(ctx, newConfigMap("ours"), resource)).To(Succeed())
+			Expect(resource.Status.Conflicts).To(HaveLen(1))
+			Expect(resource.Status.Conflicts[0].Name).To(Equal("maas-conflict-test"))
+
+			By("Taking the fields over with the Force conflict policy")
+			resource.Spec.ConflictPolicy = myappv1alpha1.ConflictPolicyForce
+			Expect(controllerReconciler.applyUnstructured(ctx, newConfigMap("ours"), resource)).To(Succeed())
+
+			configMap := &corev1.ConfigMap{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: "maas-conflict-test", Namespace: "default"}, configMap)).To(Succeed())
+			Expect(configMap.Data).To(HaveKeyWithValue("key", "ours"))
+			Expect(k8sClient.Delete(ctx, configMap)).To(Succeed())
+		})
+
+		It("should derive conditions from the component status", func() {
+			controllerReconciler := &MaasPlatformReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			resource := &myappv1alpha1.MaasPlatform{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+
+			By("reporting Progressing while maas-api is rolling out")
+			setComponentStatus(resource, myappv1alpha1.ComponentMaasAPI, myappv1alpha1.ComponentPhaseProgressing, "waiting")
+			result, err := controllerReconciler.updateStatus(ctx, resource, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).NotTo(BeZero())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.ObservedGeneration).To(Equal(resource.Generation))
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, myappv1alpha1.ConditionProgressing)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(resource.Status.Conditions, myappv1alpha1.ConditionReady)).To(BeTrue())
+
+			By("reporting Ready once every component is ready")
+			setComponentStatus(resource, myappv1alpha1.ComponentMaasAPI, myappv1alpha1.ComponentPhaseReady, "available")
+			_, err = controllerReconciler.updateStatus(ctx, resource, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.Components).To(HaveLen(1))
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, myappv1alpha1.ConditionReady)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(resource.Status.Conditions, myappv1alpha1.ConditionDegraded)).To(BeTrue())
+		})
+	})
+})
+
+var _ = Describe("maas-api database volume", func() {
+	It("should only ever expand an existing PVC", func() {
+		existing := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "maas-platform-api-db", Namespace: "maas-api"},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("2Gi")},
+				},
+			},
+		}
+		reconciler := &MaasPlatformReconciler{Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(existing).Build()}
+
+		expand := func(size string) string {
+			current := &unstructured.Unstructured{}
+			current.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim"))
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(existing), current)).To(Succeed())
+
+			desired := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			Expect(unstructured.SetNestedField(desired.Object, size, "spec", "resources", "requests", "storage")).To(Succeed())
+			Expect(reconciler.expandVolume(ctx, current, desired)).To(Succeed())
+
+			pvc := &corev1.PersistentVolumeClaim{}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(existing), pvc)).To(Succeed())
+			return pvc.Spec.Resources.Requests.Storage().String()
+		}
+		Expect(expand("1Gi")).To(Equal("2Gi"))
+		Expect(expand("5Gi")).To(Equal("5Gi"))
+	})
+})
+
+var _ = Describe("Platform names", func() {
+	It("should render distinct objects for every platform except the shared ones", func() {
+		dev := &myappv1alpha1.MaasPlatform{ObjectMeta: metav1.ObjectMeta{Name: "maas-dev", Namespace: "maas-dev"}}
+		prod := &myappv1alpha1.MaasPlatform{ObjectMeta: metav1.ObjectMeta{Name: "maas-prod", Namespace: "maas-prod"}}
+
+		keysOf := func(maasPlatform *myappv1alpha1.MaasPlatform) map[string]bool {
+			platform, err := render.RenderPlatform(maasPlatform, render.ClusterFacts{})
+			Expect(err).NotTo(HaveOccurred())
+
+			keys := make(map[string]bool)
+			for _, group := range [][]*unstructured.Unstructured{
+				platform.Kuadrant, platform.Networking, platform.MaasAPI, platform.MaasAPIPolicies, platform.GatewayAuthPolicy,
+			} {
+				for _, obj := range group {
+					if isSharedResource(obj.GetKind(), obj.GetName()) {
+						continue
+					}
+					keys[fmt.Sprintf("%s/%s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())] = true
+				}
+			}
+			return keys
+		}
+
+		devKeys := keysOf(dev)
+		Expect(devKeys).To(HaveKey("Gateway/openshift-ingress/maas-dev-gateway"))
+		Expect(devKeys).To(HaveKey("Deployment/maas-api/maas-dev-api"))
+		for key := range keysOf(prod) {
+			Expect(devKeys).NotTo(HaveKey(key))
+		}
+	})
+
+	It("should keep the legacy names of a platform deployed by an earlier release", func() {
+		platform := &myappv1alpha1.MaasPlatform{ObjectMeta: metav1.ObjectMeta{Name: "maas-platform", Namespace: "maas-system", UID: "maas-platform"}}
+		legacyDeployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name:      render.LegacyNames.MaasAPI,
+			Namespace: myappv1alpha1.DefaultAPINamespace,
+		}}
+		newReconciler := func(objects ...client.Object) *MaasPlatformReconciler {
+			return &MaasPlatformReconciler{Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objects...).Build()}
+		}
+
+		legacy, err := newReconciler(platform).deployedWithLegacyNames(ctx, platform)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(legacy).To(BeFalse())
+
+		legacy, err = newReconciler(platform, legacyDeployment.DeepCopy()).deployedWithLegacyNames(ctx, platform)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(legacy).To(BeTrue())
+
+		By("leaving the legacy objects to the platform that already uses them")
+		other := &myappv1alpha1.MaasPlatform{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "maas-system", UID: "other",
+			Annotations: map[string]string{myappv1alpha1.NamingAnnotation: myappv1alpha1.NamingLegacy}}}
+		legacy, err = newReconciler(platform, other, legacyDeployment.DeepCopy()).deployedWithLegacyNames(ctx, platform)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(legacy).To(BeFalse())
+
+		labelled := legacyDeployment.DeepCopy()
+		render.SetManagedLabels(labelled, other)
+		legacy, err = newReconciler(platform, labelled).deployedWithLegacyNames(ctx, platform)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(legacy).To(BeFalse())
+
+		platform.Annotations = other.Annotations
+		Expect(render.NamesFor(platform).Gateway).To(Equal("maas-default-gateway"))
+		Expect(render.NamesFor(platform).MaasAPIDatabase).To(Equal("maas-api-db"))
+	})
+})
+
+var _ = Describe("Prerequisites", func() {
+	It("should list exactly the APIs the cluster does not serve", func() {
+		discoveryClient := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{
+			Resources: []*metav1.APIResourceList{
+				{
+					GroupVersion: "gateway.networking.k8s.io/v1",
+					APIResources: []metav1.APIResource{{Kind: "GatewayClass"}, {Kind: "Gateway"}, {Kind: "HTTPRoute"}},
+				},
+				{
+					GroupVersion: "kuadrant.io/v1",
+					APIResources: []metav1.APIResource{{Kind: "AuthPolicy"}},
+				},
+			},
+		}}
+
+		missing, err := missingPrerequisites(discoveryClient)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(missing).To(ConsistOf(
+			"Kuadrant (kuadrant.io/v1beta1 Kuadrant)",
+			"Kuadrant (kuadrant.io/v1 RateLimitPolicy)",
+			"Kuadrant (kuadrant.io/v1alpha1 TokenRateLimitPolicy)",
+			"KServe (serving.kserve.io/v1alpha1 LLMInferenceService)",
+		))
+	})
+
+	It("should set the PrerequisitesMissing condition when APIs are missing", func() {
+		maasPlatform := &myappv1alpha1.MaasPlatform{}
+		reconciler := &MaasPlatformReconciler{Discovery: &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}}}
+
+		err := reconciler.checkPrerequisites(maasPlatform)
+		Expect(err).To(BeAssignableToTypeOf(&prerequisitesMissingError{}))
+		Expect(meta.IsStatusConditionTrue(maasPlatform.Status.Conditions, myappv1alpha1.ConditionPrerequisitesMissing)).To(BeTrue())
+	})
+})
+
+// watchRecorder is a controller that records the watches added to it
+type watchRecorder struct {
+	controller.Controller
+	sources []source.Source
+}
+
+func (c *watchRecorder) Watch(src source.Source) error {
+	c.sources = append(c.sources, src)
+	return nil
+}
+
+var _ = Describe("Managed watches", func() {
+	It("should watch the kinds installed after the manager started once their CRD is", func() {
+		mapper := meta.NewDefaultRESTMapper(nil)
+		mapper.Add(appsv1.SchemeGroupVersion.WithKind("Deployment"), meta.RESTScopeNamespace)
+		recorder := &watchRecorder{}
+		w := &managedWatches{mapper: mapper, log: GinkgoLogr}
+
+		Expect(w.kindInstalled(&appsv1.Deployment{})).To(BeTrue())
+		w.watch(nil, newUnstructured(gatewayGVK), &handler.EnqueueRequestForObject{})
+		Expect(w.pending).To(HaveLen(1))
+
+		By("waiting for the controller to be built")
+		mapper.Add(gatewayGVK, meta.RESTScopeNamespace)
+		w.addInstalled()
+		Expect(w.pending).To(HaveLen(1))
+
+		w.controller = recorder
+		w.addInstalled()
+		Expect(recorder.sources).To(HaveLen(1))
+		Expect(w.pending).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Optional components", func() {
+	var platform *myappv1alpha1.MaasPlatform
+
+	kuadrant := func() *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(kuadrantGVK)
+		obj.SetNamespace(myappv1alpha1.DefaultKuadrantNamespace)
+		obj.SetName("kuadrant")
+		return obj
+	}
+
+	BeforeEach(func() {
+		platform = &myappv1alpha1.MaasPlatform{ObjectMeta: metav1.ObjectMeta{Name: "maas-platform", Namespace: "default"}}
+	})
+
+	It("should create optional components by default", func() {
+		reconciler := &MaasPlatformReconciler{Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()}
+
+		skip, err := reconciler.reconcileOptionalComponent(ctx, kuadrant(), platform, myappv1alpha1.ComponentKuadrant)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(skip).To(BeFalse())
+		Expect(platform.Status.Components).To(ConsistOf(HaveField("Mode", myappv1alpha1.ComponentModeCreate)))
+	})
+
+	It("should skip disabled components and drop them from the inventory", func() {
+		reconciler := &MaasPlatformReconciler{Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()}
+		platform.Spec.Components = &myappv1alpha1.ComponentsConfig{
+			Kuadrant: &myappv1alpha1.ComponentConfig{Mode: myappv1alpha1.ComponentModeDisabled},
+		}
+		recordManagedResource(platform, kuadrant())
+
+		skip, err := reconciler.reconcileOptionalComponent(ctx, kuadrant(), platform, myappv1alpha1.ComponentKuadrant)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(skip).To(BeTrue())
+		Expect(platform.Status.ManagedResources).To(BeEmpty())
+		Expect(platform.Status.Components[0].Mode).To(Equal(myappv1alpha1.ComponentModeDisabled))
+	})
+
+	It("should only verify that adopted components exist", func() {
+		platform.Spec.Components = &myappv1alpha1.ComponentsConfig{
+			Kuadrant: &myappv1alpha1.ComponentConfig{Mode: myappv1alpha1.ComponentModeAdopt},
+		}
+
+		reconciler := &MaasPlatformReconciler{Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()}
+		skip, err := reconciler.reconcileOptionalComponent(ctx, kuadrant(), platform, myappv1alpha1.ComponentKuadrant)
+		Expect(err).To(MatchError(ContainSubstring("adopted Kuadrant kuadrant-system/kuadrant does not exist")))
+		Expect(skip).To(BeTrue())
+		Expect(platform.Status.Components[0].Phase).To(Equal(myappv1alpha1.ComponentPhaseFailed))
+
+		reconciler = &MaasPlatformReconciler{Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(kuadrant()).Build()}
+		skip, err = reconciler.reconcileOptionalComponent(ctx, kuadrant(), platform, myappv1alpha1.ComponentKuadrant)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(skip).To(BeTrue())
+		Expect(platform.Status.Components[0].Phase).To(Equal(myappv1alpha1.ComponentPhaseReady))
+		Expect(platform.Status.ManagedResources).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Plan", func() {
+	configMap := func(value string) *unstructured.Unstructured {
+		obj := newUnstructured(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+		obj.SetNamespace("maas-api")
+		obj.SetName("maas-platform-config")
+		Expect(unstructured.SetNestedField(obj.Object, value, "data", "key")).To(Succeed())
+		return obj
+	}
+
+	It("should list the changed fields and ignore bookkeeping", func() {
+		live := map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "gateway", "resourceVersion": "1", "generation": int64(1)},
+			"spec": map[string]interface{}{
+				"listeners": []interface{}{map[string]interface{}{"name": "http", "port": int64(80)}},
+				"class":     "openshift-default",
+			},
+			"status": map[string]interface{}{"ready": false},
+		}
+		applied := runtime.DeepCopyJSON(live)
+		Expect(changedFields(live, applied)).To(BeEmpty())
+
+		Expect(unstructured.SetNestedField(applied, "2", "metadata", "resourceVersion")).To(Succeed())
+		Expect(unstructured.SetNestedField(applied, true, "status", "ready")).To(Succeed())
+		Expect(unstructured.SetNestedField(applied, "maas", "metadata", "labels", "app")).To(Succeed())
+		Expect(unstructured.SetNestedSlice(applied, []interface{}{
+			map[string]interface{}{"name": "http", "port": int64(8080)},
+		}, "spec", "listeners")).To(Succeed())
+		Expect(changedFields(live, applied)).To(Equal([]string{"metadata.labels", "spec.listeners"}))
+	})
+
+	It("should plan creates, updates and deletes without writing", func() {
+		existing := configMap("old")
+		reconciler := &MaasPlatformReconciler{Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(existing).Build()}
+		p := newPlanner(reconciler.Client)
+
+		Expect(p.apply(ctx, configMap("old"), true)).To(Succeed())
+		Expect(p.changes).To(BeEmpty())
+
+		Expect(p.apply(ctx, configMap("new"), true)).To(Succeed())
+		created := configMap("new")
+		created.SetName("maas-platform-other")
+		Expect(p.apply(ctx, created, true)).To(Succeed())
+		Expect(p.delete(ctx, configMap(""))).To(Succeed())
+		missing := configMap("")
+		missing.SetName("maas-platform-missing")
+		Expect(p.delete(ctx, missing)).To(Succeed())
+
+		plan := p.plan()
+		Expect(plan.Summary).To(Equal("1 to create, 1 to update, 1 to delete"))
+		Expect(plan.Changes).To(HaveLen(3))
+		Expect(plan.Changes[0].Action).To(Equal(myappv1alpha1.PlannedActionUpdate))
+		Expect(plan.Changes[0].Fields).To(Equal([]string{"data.key"}))
+		Expect(plan.Changes[1]).To(HaveField("Action", myappv1alpha1.PlannedActionCreate))
+		Expect(plan.Changes[1]).To(HaveField("Name", "maas-platform-other"))
+		Expect(plan.Changes[2]).To(HaveField("Action", myappv1alpha1.PlannedActionDelete))
+		Expect(planMessage(plan)).To(ContainSubstring("Update ConfigMap maas-api/maas-platform-config"))
+
+		current := &corev1.ConfigMap{}
+		Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(existing), current)).To(Succeed())
+		Expect(current.Data).To(HaveKeyWithValue("key", "old"))
+	})
+
+	It("should list the objects the spec no longer asks for", func() {
+		platform := &myappv1alpha1.MaasPlatform{ObjectMeta: metav1.ObjectMeta{Name: "maas-platform", Namespace: "default"}}
+		kinds := func(objects []*unstructured.Unstructured) []string {
+			var kinds []string
+			for _, obj := range objects {
+				kinds = append(kinds, obj.GetKind())
+			}
+			return kinds
+		}
+		Expect(kinds(obsoleteMaasAPIObjects(platform))).To(Equal([]string{"PodDisruptionBudget", "HorizontalPodAutoscaler"}))
+		Expect(kinds(obsoleteNetworkingObjects(platform))).To(Equal([]string{"HTTPRoute"}))
+
+		platform.Spec.Gateway = &myappv1alpha1.GatewayConfig{RedirectHTTPToHTTPS: true}
+		Expect(obsoleteNetworkingObjects(platform)).To(BeEmpty())
+	})
+
+	It("should only plan in the Plan management state or when the operator plans everything", func() {
+		platform := &myappv1alpha1.MaasPlatform{}
+		Expect(planOnly(false, platform)).To(BeFalse())
+		Expect(planOnly(true, platform)).To(BeTrue())
+		platform.Spec.ManagementState = myappv1alpha1.ManagementStatePlan
+		Expect(planOnly(false, platform)).To(BeTrue())
+	})
+})
+
+var _ = Describe("Management state", func() {
+	var platform *myappv1alpha1.MaasPlatform
+
+	configMap := func(name string) *unstructured.Unstructured {
+		obj := newUnstructured(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+		obj.SetNamespace("maas-api")
+		obj.SetName(name)
+		return obj
+	}
+
+	BeforeEach(func() {
+		platform = &myappv1alpha1.MaasPlatform{ObjectMeta: metav1.ObjectMeta{Name: "maas-platform", Namespace: "default"}}
+	})
+
+	It("should apply the platform management state to every component", func() {
+		platform.Spec.Components = &myappv1alpha1.ComponentsConfig{
+			TierPolicies: &myappv1alpha1.ManagedComponentConfig{ManagementState: myappv1alpha1.ManagementStateUnmanaged},
+		}
+		Expect(platform.Spec.ComponentManagementState(myappv1alpha1.ComponentMaasAPI)).To(Equal(myappv1alpha1.ManagementStateManaged))
+		Expect(platform.Spec.ComponentManagementState(myappv1alpha1.ComponentTierPolicies)).To(Equal(myappv1alpha1.ManagementStateUnmanaged))
+
+		platform.Spec.ManagementState = myappv1alpha1.ManagementStateRemoved
+		Expect(platform.Spec.ComponentManagementState(myappv1alpha1.ComponentMaasAPI)).To(Equal(myappv1alpha1.ManagementStateRemoved))
+		Expect(platform.Spec.ComponentManagementState(myappv1alpha1.ComponentTierPolicies)).To(Equal(myappv1alpha1.ManagementStateRemoved))
+	})
+
+	It("should suspend paused and unmanaged platforms", func() {
+		reason, _ := suspension(platform)
+		Expect(reason).To(BeEmpty())
+
+		platform.Spec.ManagementState = myappv1alpha1.ManagementStateUnmanaged
+		reason, _ = suspension(platform)
+		Expect(reason).To(Equal(myappv1alpha1.ManagementStateUnmanaged))
+
+		platform.Annotations = map[string]string{myappv1alpha1.PausedAnnotation: "true"}
+		reason, message := suspension(platform)
+		Expect(reason).To(Equal("Paused"))
+		Expect(message).To(ContainSubstring(myappv1alpha1.PausedAnnotation))
+	})
+
+	It("should hold the deletion of a platform that is not managed", func() {
+		now := metav1.Now()
+		platform.Finalizers = []string{maasPlatformFinalizer}
+		platform.DeletionTimestamp = &now
+		platform.Annotations = map[string]string{myappv1alpha1.PausedAnnotation: "true"}
+		reconciler := &MaasPlatformReconciler{Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).
+			WithObjects(platform).WithStatusSubresource(platform).Build()}
+		request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(platform)}
+
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		blocked := &myappv1alpha1.MaasPlatform{}
+		Expect(reconciler.Get(ctx, request.NamespacedName, blocked)).To(Succeed())
+		Expect(blocked.Finalizers).To(ContainElement(maasPlatformFinalizer))
+		Expect(meta.FindStatusCondition(blocked.Status.Conditions, myappv1alpha1.ConditionReady).Reason).To(Equal("DeletionBlocked"))
+
+		// Retained resources need no cleanup, so the platform is released
+		blocked.Spec.DeletionPolicy = myappv1alpha1.DeletionPolicyRetain
+		Expect(reconciler.Update(ctx, blocked)).To(Succeed())
+		_, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(errors.IsNotFound(reconciler.Get(ctx, request.NamespacedName, &myappv1alpha1.MaasPlatform{}))).To(BeTrue())
+
+		platform.Annotations = nil
+		Expect(deletionBlocked(false, platform)).To(BeEmpty())
+		Expect(deletionBlocked(true, platform)).NotTo(BeEmpty())
+		platform.Spec.ManagementState = myappv1alpha1.ManagementStatePlan
+		Expect(deletionBlocked(false, platform)).To(ContainSubstring("Plan"))
+	})
+
+	It("should leave unmanaged components alone", func() {
+		existing := configMap("maas-platform-config")
+		reconciler := &MaasPlatformReconciler{Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(existing).Build()}
+		platform.Spec.Components = &myappv1alpha1.ComponentsConfig{
+			MaasAPI: &myappv1alpha1.ManagedComponentConfig{ManagementState: myappv1alpha1.ManagementStateUnmanaged},
+		}
+
+		skip, err := reconciler.skipComponent(ctx, platform, myappv1alpha1.ComponentMaasAPI, []*unstructured.Unstructured{configMap("maas-platform-config")})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(skip).To(BeTrue())
+		Expect(platform.Status.Components).To(ConsistOf(HaveField("Phase", myappv1alpha1.ComponentPhaseSuspended)))
+		Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(existing), &corev1.ConfigMap{})).To(Succeed())
+
+		setSuspendedCondition(platform)
+		Expect(meta.FindStatusCondition(platform.Status.Conditions, myappv1alpha1.ConditionSuspended).Message).To(ContainSubstring("maas-api"))
+	})
+
+	It("should delete the objects of removed components, except the shared ones other platforms use", func() {
+		kuadrant := newUnstructured(kuadrantGVK)
+		kuadrant.SetNamespace(myappv1alpha1.DefaultKuadrantNamespace)
+		kuadrant.SetName("kuadrant")
+		other := &myappv1alpha1.MaasPlatform{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default", UID: "other"}}
+		platform.UID = "maas-platform"
+		reconciler := &MaasPlatformReconciler{Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).
+			WithObjects(configMap("maas-platform-config"), kuadrant.DeepCopy(), other).Build()}
+		platform.Spec.Components = &myappv1alpha1.ComponentsConfig{
+			Networking: &myappv1alpha1.ManagedComponentConfig{ManagementState: myappv1alpha1.ManagementStateRemoved},
+		}
+		recordManagedResource(platform, configMap("maas-platform-config"))
+
+		skip, err := reconciler.skipComponent(ctx, platform, myappv1alpha1.ComponentNetworking,
+			[]*unstructured.Unstructured{configMap("maas-platform-config"), kuadrant}, []*unstructured.Unstructured{configMap("missing")})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(skip).To(BeTrue())
+		Expect(platform.Status.Components).To(ConsistOf(HaveField("Phase", myappv1alpha1.ComponentPhaseRemoved)))
+		Expect(platform.Status.ManagedResources).To(BeEmpty())
+
+		err = reconciler.Get(ctx, client.ObjectKey{Namespace: "maas-api", Name: "maas-platform-config"}, &corev1.ConfigMap{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(kuadrant), newUnstructured(kuadrantGVK))).To(Succeed())
+	})
+})
+
+var _ = Describe("Deployment phases", func() {
+	var platform *myappv1alpha1.MaasPlatform
+
+	BeforeEach(func() {
+		platform = &myappv1alpha1.MaasPlatform{ObjectMeta: metav1.ObjectMeta{Name: "maas-platform", Namespace: "default"}}
+	})
+
+	withCondition := func(obj *unstructured.Unstructured, conditionType, status, message string) *unstructured.Unstructured {
+		obj = obj.DeepCopy()
+		Expect(unstructured.SetNestedSlice(obj.Object, []interface{}{
+			map[string]interface{}{"type": conditionType, "status": status, "message": message},
+		}, "status", "conditions")).To(Succeed())
+		return obj
+	}
+
+	It("should wait for Kuadrant to become ready", func() {
+		desired, err := render.RenderPlatform(platform, render.ClusterFacts{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(desired.Kuadrant).To(HaveLen(1))
+
+		reconciler := &MaasPlatformReconciler{Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()}
+		err = reconciler.checkKuadrantReady(ctx, platform, desired.Kuadrant)
+		var notReady *phaseNotReadyError
+		Expect(err).To(BeAssignableToTypeOf(notReady))
+		Expect(err.(*phaseNotReadyError).phase).To(Equal(myappv1alpha1.DeploymentPhaseKuadrant))
+
+		ready := withCondition(desired.Kuadrant[0], "Ready", "True", "")
+		reconciler = &MaasPlatformReconciler{Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(ready).Build()}
+		Expect(reconciler.checkKuadrantReady(ctx, platform, desired.Kuadrant)).To(Succeed())
+		Expect(platform.Status.Components).To(ContainElement(HaveField("Phase", myappv1alpha1.ComponentPhaseReady)))
+
+		By("not waiting for a Kuadrant the platform does not use")
+		platform.Spec.Components = &myappv1alpha1.ComponentsConfig{
+			Kuadrant: &myappv1alpha1.ComponentConfig{Mode: myappv1alpha1.ComponentModeDisabled},
+		}
+		reconciler = &MaasPlatformReconciler{Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()}
+		Expect(reconciler.checkKuadrantReady(ctx, platform, desired.Kuadrant)).To(Succeed())
+	})
+
+	It("should wait for the platform gateway to be programmed", func() {
+		desired, err := render.RenderPlatform(platform, render.ClusterFacts{})
+		Expect(err).NotTo(HaveOccurred())
+		var gateway *unstructured.Unstructured
+		for _, obj := range desired.Networking {
+			if obj.GetKind() == "Gateway" && obj.GetName() == render.NamesFor(platform).Gateway {
+				gateway = obj
+			}
+		}
+		Expect(gateway).NotTo(BeNil())
+
+		pending := withCondition(gateway, "Programmed", "False", "Waiting for the load balancer")
+		reconciler := &MaasPlatformReconciler{Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(pending).Build()}
+		err = reconciler.checkGatewayProgrammed(ctx, platform, desired.Networking)
+		Expect(err).To(MatchError(ContainSubstring("Waiting for the load balancer")))
+		Expect(err.(*phaseNotReadyError).phase).To(Equal(myappv1alpha1.DeploymentPhaseGateway))
+
+		programmed := withCondition(gateway, "Programmed", "True", "")
+		reconciler = &MaasPlatformReconciler{Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(programmed).Build()}
+		Expect(reconciler.checkGatewayProgrammed(ctx, platform, desired.Networking)).To(Succeed())
+		Expect(platform.Status.Components).To(ConsistOf(HaveField("Phase", myappv1alpha1.ComponentPhaseReady)))
+	})
+
+	It("should hold the policies back until maas-api is available", func() {
+		reconciler := &MaasPlatformReconciler{Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()}
+		err := reconciler.checkMaasAPIAvailable(ctx, platform)
+		Expect(err).To(HaveOccurred())
+		Expect(err.(*phaseNotReadyError).phase).To(Equal(myappv1alpha1.DeploymentPhaseMaasAPI))
+		Expect(platform.Status.Components).To(ConsistOf(HaveField("Phase", myappv1alpha1.ComponentPhaseProgressing)))
+	})
+
+	It("should back off the longer a phase is blocked", func() {
+		Expect(phaseBackoff(platform)).To(Equal(minPhaseBackoff))
+
+		setDeploymentPhase(platform, myappv1alpha1.DeploymentPhaseGateway)
+		entered := metav1.NewTime(time.Now().Add(-30 * time.Second))
+		platform.Status.PhaseTransitionTime = &entered
+		Expect(phaseBackoff(platform)).To(BeNumerically("~", 30*time.Second, time.Second))
+
+		By("keeping the transition time while the phase stays the same")
+		setDeploymentPhase(platform, myappv1alpha1.DeploymentPhaseGateway)
+		Expect(platform.Status.PhaseTransitionTime).To(Equal(&entered))
+
+		longAgo := metav1.NewTime(time.Now().Add(-time.Hour))
+		platform.Status.PhaseTransitionTime = &longAgo
+		Expect(phaseBackoff(platform)).To(Equal(maxPhaseBackoff))
+	})
+
+	It("should let the Tier policies through from the policies phase on", func() {
+		Expect(policiesPhaseReached(platform)).To(BeFalse())
+		platform.Status.Phase = myappv1alpha1.DeploymentPhaseGateway
+		Expect(policiesPhaseReached(platform)).To(BeFalse())
+		platform.Status.Phase = myappv1alpha1.DeploymentPhasePolicies
+		Expect(policiesPhaseReached(platform)).To(BeTrue())
+		platform.Status.Phase = myappv1alpha1.DeploymentPhaseComplete
+		Expect(policiesPhaseReached(platform)).To(BeTrue())
+
+		By("not holding them back again once the platform has applied its policies")
+		platform.Status.Phase = myappv1alpha1.DeploymentPhaseMaasAPI
+		Expect(policiesPhaseReached(platform)).To(BeFalse())
+		platform.Status.ManagedResources = []myappv1alpha1.ManagedResource{{
+			APIVersion: authPolicyGVK.GroupVersion().String(), Kind: "AuthPolicy",
+			Namespace: myappv1alpha1.DefaultAPINamespace, Name: "maas-platform-api-auth-policy",
+		}}
+		Expect(policiesPhaseReached(platform)).To(BeTrue())
 	})
 })
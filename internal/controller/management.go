@@ -0,0 +1,226 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+	"github.com/jland-redhat/maas-operator.git/pkg/render"
+)
+
+// managedComponents are the platform components whose management state can be set, in apply order
+var managedComponents = []string{
+	myappv1alpha1.ComponentNetworking,
+	myappv1alpha1.ComponentMaasAPI,
+	myappv1alpha1.ComponentGatewayAuthPolicy,
+	myappv1alpha1.ComponentTierPolicies,
+}
+
+// suspension returns the reason and message when the reconciliation of a whole MaasPlatform
+// is suspended, by the paused annotation or the Unmanaged management state
+func suspension(maasPlatform *myappv1alpha1.MaasPlatform) (string, string) {
+	switch {
+	case maasPlatform.IsPaused():
+		return "Paused", fmt.Sprintf("Reconciliation is paused by the %s annotation", myappv1alpha1.PausedAnnotation)
+	case maasPlatform.Spec.ManagementState == myappv1alpha1.ManagementStateUnmanaged:
+		return myappv1alpha1.ManagementStateUnmanaged, "The management state is Unmanaged, managed resources are left as they are"
+	}
+	return "", ""
+}
+
+// suspend records that a MaasPlatform is not reconciled. Only its status is written.
+func (r *MaasPlatformReconciler) suspend(ctx context.Context, maasPlatform *myappv1alpha1.MaasPlatform, reason, message string) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	log.Info("Reconciliation suspended", "reason", reason)
+
+	setCondition(maasPlatform, myappv1alpha1.ConditionSuspended, metav1.ConditionTrue, reason, message)
+	setCondition(maasPlatform, myappv1alpha1.ConditionProgressing, metav1.ConditionFalse, reason, message)
+	maasPlatform.Status.ObservedGeneration = maasPlatform.Generation
+	if err := r.Status().Update(ctx, maasPlatform); err != nil {
+		log.Error(err, "Failed to update status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// deletionBlocked returns why the managed resources of a MaasPlatform being deleted cannot be
+// deleted yet, empty when they can
+func deletionBlocked(operatorPlanOnly bool, maasPlatform *myappv1alpha1.MaasPlatform) string {
+	if _, message := suspension(maasPlatform); message != "" {
+		return message
+	}
+	switch {
+	case maasPlatform.Spec.ManagementState == myappv1alpha1.ManagementStatePlan:
+		return "The management state is Plan, nothing is deleted while changes are only planned"
+	case operatorPlanOnly:
+		return "The operator only plans changes, nothing is deleted"
+	}
+	return ""
+}
+
+// blockDeletion keeps the finalizer of a MaasPlatform being deleted while the operator may not
+// touch its resources. They are deleted once the platform is managed again.
+func (r *MaasPlatformReconciler) blockDeletion(ctx context.Context, maasPlatform *myappv1alpha1.MaasPlatform, message string) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	log.Info("Deletion blocked until the platform is managed again", "reason", message)
+
+	message = fmt.Sprintf("%s. The managed resources are deleted, and the MaasPlatform released, once it is managed again "+
+		"or its deletionPolicy is Retain", message)
+	setCondition(maasPlatform, myappv1alpha1.ConditionReady, metav1.ConditionFalse, "DeletionBlocked", message)
+	setCondition(maasPlatform, myappv1alpha1.ConditionProgressing, metav1.ConditionFalse, "DeletionBlocked", message)
+	if err := r.Status().Update(ctx, maasPlatform); err != nil {
+		log.Error(err, "Failed to update status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// removePlatform deletes the managed resources of a MaasPlatform in the Removed management state,
+// the way they are deleted with the MaasPlatform, and keeps the MaasPlatform itself
+func (r *MaasPlatformReconciler) removePlatform(ctx context.Context, maasPlatform *myappv1alpha1.MaasPlatform) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	log.Info("Removing managed resources as requested by the management state")
+
+	done, err := r.finalize(ctx, maasPlatform)
+	if err != nil {
+		log.Error(err, "Failed to remove managed resources")
+		return r.updateStatus(ctx, maasPlatform, err)
+	}
+
+	result := ctrl.Result{}
+	if done {
+		maasPlatform.Status.ManagedResources = nil
+		maasPlatform.Status.Conflicts = nil
+		maasPlatform.Status.Components = nil
+		maasPlatform.Status.Phase = ""
+		maasPlatform.Status.PhaseTransitionTime = nil
+		message := "Managed resources removed, set the management state to Managed to deploy the platform again"
+		setCondition(maasPlatform, myappv1alpha1.ConditionReady, metav1.ConditionFalse, myappv1alpha1.ManagementStateRemoved, message)
+		setCondition(maasPlatform, myappv1alpha1.ConditionProgressing, metav1.ConditionFalse, myappv1alpha1.ManagementStateRemoved, message)
+	} else {
+		message := "Waiting for managed resources to be deleted"
+		setCondition(maasPlatform, myappv1alpha1.ConditionReady, metav1.ConditionFalse, "Removing", message)
+		setCondition(maasPlatform, myappv1alpha1.ConditionProgressing, metav1.ConditionTrue, "Removing", message)
+		result.RequeueAfter = 5 * time.Second
+	}
+	setCondition(maasPlatform, myappv1alpha1.ConditionDegraded, metav1.ConditionFalse, myappv1alpha1.ManagementStateRemoved, "Managed resources are removed")
+	meta.RemoveStatusCondition(&maasPlatform.Status.Conditions, myappv1alpha1.ConditionSuspended)
+	maasPlatform.Status.ObservedGeneration = maasPlatform.Generation
+
+	if err := r.Status().Update(ctx, maasPlatform); err != nil {
+		log.Error(err, "Failed to update status")
+		return ctrl.Result{}, err
+	}
+	return result, nil
+}
+
+// skipComponent handles a platform component that is not Managed: Unmanaged components are left
+// alone and the objects of Removed ones are deleted. It returns false for managed components.
+func (r *MaasPlatformReconciler) skipComponent(ctx context.Context, maasPlatform *myappv1alpha1.MaasPlatform, component string, objects ...[]*unstructured.Unstructured) (bool, error) {
+	switch maasPlatform.Spec.ComponentManagementState(component) {
+	case myappv1alpha1.ManagementStateUnmanaged:
+		logf.FromContext(ctx).Info("Skipping unmanaged component", "component", component)
+		setComponentStatus(maasPlatform, component, myappv1alpha1.ComponentPhaseSuspended, "Not managed by the operator")
+		return true, nil
+	case myappv1alpha1.ManagementStateRemoved:
+		if err := r.removeObjects(ctx, maasPlatform, objects...); err != nil {
+			setComponentStatus(maasPlatform, component, myappv1alpha1.ComponentPhaseFailed, err.Error())
+			return true, err
+		}
+		setComponentStatus(maasPlatform, component, myappv1alpha1.ComponentPhaseRemoved, "Resources removed")
+		return true, nil
+	}
+	return false, nil
+}
+
+// removeObjects deletes the objects of a removed component and drops them from the inventory
+func (r *MaasPlatformReconciler) removeObjects(ctx context.Context, maasPlatform *myappv1alpha1.MaasPlatform, objects ...[]*unstructured.Unstructured) error {
+	removable, err := r.removableObjects(ctx, maasPlatform, objects...)
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range removable {
+		err := r.Delete(ctx, obj)
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			forgetManagedResource(maasPlatform, obj)
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to delete %s %s: %w", obj.GetKind(), objectKeyString(obj), err)
+		}
+		forgetManagedResource(maasPlatform, obj)
+		logf.FromContext(ctx).Info("Removed resource", "kind", obj.GetKind(), "name", obj.GetName(), "namespace", obj.GetNamespace())
+	}
+	return nil
+}
+
+// removableObjects returns the objects of a removed component in deletion order. Optional
+// components the operator does not create are left alone, and so are the shared resources
+// while other platforms rely on them.
+func (r *MaasPlatformReconciler) removableObjects(ctx context.Context, maasPlatform *myappv1alpha1.MaasPlatform, objects ...[]*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	others, err := r.otherPlatformsExist(ctx, maasPlatform)
+	if err != nil {
+		return nil, err
+	}
+
+	var removable []*unstructured.Unstructured
+	for _, group := range objects {
+		for _, obj := range group {
+			if component, ok := render.OptionalComponent(obj); ok && maasPlatform.Spec.ComponentMode(component) != myappv1alpha1.ComponentModeCreate {
+				continue
+			}
+			if others && isSharedResource(obj.GetKind(), obj.GetName()) {
+				continue
+			}
+			removable = append(removable, obj)
+		}
+	}
+
+	sort.SliceStable(removable, func(i, j int) bool {
+		return deletionRank(removable[i].GetKind()) < deletionRank(removable[j].GetKind())
+	})
+	return removable, nil
+}
+
+// setSuspendedCondition reports the components that are not reconciled because they are Unmanaged
+func setSuspendedCondition(maasPlatform *myappv1alpha1.MaasPlatform) {
+	var unmanaged []string
+	for _, component := range managedComponents {
+		if maasPlatform.Spec.ComponentManagementState(component) == myappv1alpha1.ManagementStateUnmanaged {
+			unmanaged = append(unmanaged, component)
+		}
+	}
+
+	if len(unmanaged) == 0 {
+		meta.RemoveStatusCondition(&maasPlatform.Status.Conditions, myappv1alpha1.ConditionSuspended)
+		return
+	}
+	setCondition(maasPlatform, myappv1alpha1.ConditionSuspended, metav1.ConditionTrue, "ComponentsUnmanaged",
+		fmt.Sprintf("Not reconciling the unmanaged components: %s", strings.Join(unmanaged, ", ")))
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+	"github.com/jland-redhat/maas-operator.git/pkg/render"
+)
+
+// sharedResources are the cluster-wide objects every MaasPlatform applies.
+// They are keyed by kind and name and only deleted with the last platform.
+var sharedResources = map[string]bool{
+	"GatewayClass/openshift-default": true,
+	"Gateway/openshift-ai-inference": true,
+	"Kuadrant/kuadrant":              true,
+}
+
+// isSharedResource reports whether an object is shared by every MaasPlatform
+func isSharedResource(kind, name string) bool {
+	return sharedResources[kind+"/"+name]
+}
+
+// deployedWithLegacyNames reports whether a MaasPlatform the operator has not reconciled yet was
+// deployed by a release that used fixed object names. Those releases did not label what they
+// deployed, so an unlabelled maas-api Deployment under the legacy name gives them away. Only one
+// platform can keep the legacy names.
+func (r *MaasPlatformReconciler) deployedWithLegacyNames(ctx context.Context, maasPlatform *myappv1alpha1.MaasPlatform) (bool, error) {
+	if _, ok := maasPlatform.Annotations[myappv1alpha1.NamingAnnotation]; ok {
+		return false, nil
+	}
+
+	platformList := &myappv1alpha1.MaasPlatformList{}
+	if err := r.List(ctx, platformList); err != nil {
+		return false, fmt.Errorf("failed to list MaasPlatforms: %w", err)
+	}
+	for _, other := range platformList.Items {
+		if other.UID != maasPlatform.UID && other.UsesLegacyNames() {
+			return false, nil
+		}
+	}
+
+	deployment := &appsv1.Deployment{}
+	key := client.ObjectKey{Name: render.LegacyNames.MaasAPI, Namespace: myappv1alpha1.DefaultAPINamespace}
+	if err := r.Get(ctx, key, deployment); errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to check for the legacy maas-api Deployment: %w", err)
+	}
+	_, labelled := platformForObject(deployment)
+	return !labelled, nil
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+	"github.com/jland-redhat/maas-operator.git/pkg/render"
+)
+
+const (
+	// minPhaseBackoff is the first requeue delay of a blocked deployment phase
+	minPhaseBackoff = 5 * time.Second
+	// maxPhaseBackoff caps the requeue delay of a blocked deployment phase
+	maxPhaseBackoff = 2 * time.Minute
+)
+
+// phaseNotReadyError reports that a deployment phase is waiting for a resource to become ready.
+// It is not a failure: the phases after it are held back until the resource is ready.
+type phaseNotReadyError struct {
+	phase   string
+	message string
+}
+
+func (e *phaseNotReadyError) Error() string {
+	return e.message
+}
+
+// setDeploymentPhase records the deployment phase of a MaasPlatform, and when it was entered
+func setDeploymentPhase(maasPlatform *myappv1alpha1.MaasPlatform, phase string) {
+	if maasPlatform.Status.Phase == phase {
+		return
+	}
+	now := metav1.Now()
+	maasPlatform.Status.Phase = phase
+	maasPlatform.Status.PhaseTransitionTime = &now
+}
+
+// updatePhaseStatus records the deployment phase the reconcile stopped in and writes the status
+func (r *MaasPlatformReconciler) updatePhaseStatus(ctx context.Context, maasPlatform *myappv1alpha1.MaasPlatform, phase string, reconcileErr error) (ctrl.Result, error) {
+	setDeploymentPhase(maasPlatform, phase)
+	return r.updateStatus(ctx, maasPlatform, reconcileErr)
+}
+
+// phaseBackoff returns how long to wait before checking a blocked phase again. The delay grows
+// with the time already spent in the phase, so it roughly doubles on every check, within bounds.
+func phaseBackoff(maasPlatform *myappv1alpha1.MaasPlatform) time.Duration {
+	wait := minPhaseBackoff
+	if since := maasPlatform.Status.PhaseTransitionTime; since != nil {
+		wait = time.Since(since.Time)
+	}
+	return min(max(wait, minPhaseBackoff), maxPhaseBackoff)
+}
+
+// checkKuadrantReady holds the Kuadrant phase until the Kuadrant instance reports Ready.
+// An instance the platform does not use is not waited for, an adopted one is.
+func (r *MaasPlatformReconciler) checkKuadrantReady(ctx context.Context, maasPlatform *myappv1alpha1.MaasPlatform, objects []*unstructured.Unstructured) error {
+	component := myappv1alpha1.ComponentKuadrant
+	mode := maasPlatform.Spec.ComponentMode(component)
+	if mode == myappv1alpha1.ComponentModeDisabled {
+		return nil
+	}
+
+	for _, obj := range objects {
+		ready, message, err := r.conditionTrue(ctx, obj, "Ready")
+		if err != nil {
+			return err
+		}
+		if !ready {
+			message = fmt.Sprintf("Waiting for Kuadrant %s to become ready%s", objectKeyString(obj), message)
+			setOptionalComponentStatus(maasPlatform, component, mode, myappv1alpha1.ComponentPhaseProgressing, message)
+			return &phaseNotReadyError{phase: myappv1alpha1.DeploymentPhaseKuadrant, message: message}
+		}
+		setOptionalComponentStatus(maasPlatform, component, mode, myappv1alpha1.ComponentPhaseReady, fmt.Sprintf("Kuadrant %s is ready", objectKeyString(obj)))
+	}
+	return nil
+}
+
+// checkGatewayProgrammed holds the Gateway phase until the platform gateway reports Programmed
+func (r *MaasPlatformReconciler) checkGatewayProgrammed(ctx context.Context, maasPlatform *myappv1alpha1.MaasPlatform, objects []*unstructured.Unstructured) error {
+	name := render.NamesFor(maasPlatform).Gateway
+	for _, obj := range objects {
+		if obj.GetKind() != "Gateway" || obj.GetName() != name {
+			continue
+		}
+
+		programmed, message, err := r.conditionTrue(ctx, obj, "Programmed")
+		if err != nil {
+			return err
+		}
+		if !programmed {
+			message = fmt.Sprintf("Waiting for Gateway %s to be programmed%s", objectKeyString(obj), message)
+			setComponentStatus(maasPlatform, myappv1alpha1.ComponentNetworking, myappv1alpha1.ComponentPhaseProgressing, message)
+			return &phaseNotReadyError{phase: myappv1alpha1.DeploymentPhaseGateway, message: message}
+		}
+		setComponentStatus(maasPlatform, myappv1alpha1.ComponentNetworking, myappv1alpha1.ComponentPhaseReady,
+			fmt.Sprintf("Gateway %s is programmed", objectKeyString(obj)))
+	}
+	return nil
+}
+
+// conditionTrue reports whether the live copy of an object has a condition set to True.
+// When it does not, the returned message carries the condition message, if any, to append
+// to the waiting message.
+func (r *MaasPlatformReconciler) conditionTrue(ctx context.Context, obj *unstructured.Unstructured, conditionType string) (bool, string, error) {
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(obj.GroupVersionKind())
+	if err := r.Get(ctx, client.ObjectKeyFromObject(obj), live); errors.IsNotFound(err) {
+		return false, "", nil
+	} else if err != nil {
+		return false, "", fmt.Errorf("failed to get %s %s: %w", obj.GetKind(), objectKeyString(obj), err)
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(live.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != conditionType {
+			continue
+		}
+		if condition["status"] == string(metav1.ConditionTrue) {
+			return true, "", nil
+		}
+		if message, _ := condition["message"].(string); message != "" {
+			return false, ": " + message, nil
+		}
+	}
+	return false, "", nil
+}
@@ -0,0 +1,406 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+	"github.com/jland-redhat/maas-operator.git/pkg/render"
+)
+
+// planRequeueInterval is how often a plan is refreshed. Only some managed kinds are watched,
+// so changes to the others would otherwise go unnoticed.
+const planRequeueInterval = 5 * time.Minute
+
+// maxEventMessage keeps plan Events well under the size the API server accepts
+const maxEventMessage = 1024
+
+// ignoredPlanFields are changed by every write or by other controllers, never by the operator
+var ignoredPlanFields = [][]string{
+	{"metadata", "managedFields"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "generation"},
+	{"metadata", "uid"},
+	{"metadata", "creationTimestamp"},
+	{"status"},
+}
+
+// planOnly reports whether the changes for a MaasPlatform are only planned, either because of
+// its management state or because the whole operator runs in plan mode
+func planOnly(operatorPlanOnly bool, maasPlatform *myappv1alpha1.MaasPlatform) bool {
+	return operatorPlanOnly || maasPlatform.Spec.ManagementState == myappv1alpha1.ManagementStatePlan
+}
+
+// planPlatform plans the changes applying the rendered platform would make and publishes them in
+// the status and as an Event. Only the status of the MaasPlatform is written.
+func (r *MaasPlatformReconciler) planPlatform(ctx context.Context, maasPlatform *myappv1alpha1.MaasPlatform, desired *render.Platform) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	p := newPlanner(r.Client)
+
+	// A removed platform keeps its namespaces but gets no new ones
+	if maasPlatform.Spec.ManagementState != myappv1alpha1.ManagementStateRemoved {
+		for _, ns := range desired.Namespaces {
+			if err := p.apply(ctx, ns, true); err != nil {
+				log.Error(err, "Failed to plan namespaces")
+				return r.updateStatus(ctx, maasPlatform, err)
+			}
+		}
+	}
+
+	// Same phases as an apply, without waiting for readiness, each followed by the objects
+	// the spec no longer asks for
+	steps := []struct {
+		component string
+		objects   []*unstructured.Unstructured
+		obsolete  []*unstructured.Unstructured
+	}{
+		{myappv1alpha1.ComponentNetworking, desired.Kuadrant, nil},
+		{myappv1alpha1.ComponentNetworking, desired.Networking, obsoleteNetworkingObjects(maasPlatform)},
+		{myappv1alpha1.ComponentMaasAPI, desired.MaasAPI, obsoleteMaasAPIObjects(maasPlatform)},
+		{myappv1alpha1.ComponentMaasAPI, desired.MaasAPIPolicies, nil},
+		{myappv1alpha1.ComponentGatewayAuthPolicy, desired.GatewayAuthPolicy, nil},
+	}
+	for _, step := range steps {
+		switch maasPlatform.Spec.ComponentManagementState(step.component) {
+		case myappv1alpha1.ManagementStateUnmanaged:
+			continue
+		case myappv1alpha1.ManagementStateRemoved:
+			removable, err := r.removableObjects(ctx, maasPlatform, step.objects, step.obsolete)
+			if err == nil {
+				err = p.deleteAll(ctx, removable)
+			}
+			if err != nil {
+				log.Error(err, "Failed to plan removed resources")
+				return r.updateStatus(ctx, maasPlatform, err)
+			}
+			continue
+		}
+
+		if err := r.planObjects(ctx, p, step.objects, maasPlatform); err != nil {
+			log.Error(err, "Failed to plan resources")
+			return r.updateStatus(ctx, maasPlatform, err)
+		}
+		if err := p.deleteAll(ctx, step.obsolete); err != nil {
+			log.Error(err, "Failed to plan obsolete resources")
+			return r.updateStatus(ctx, maasPlatform, err)
+		}
+	}
+
+	plan := p.plan()
+	previous := maasPlatform.Status.Plan
+	log.Info("Planned changes, nothing applied", "summary", plan.Summary)
+
+	maasPlatform.Status.Plan = plan
+	status := metav1.ConditionFalse
+	if len(plan.Changes) > 0 {
+		status = metav1.ConditionTrue
+	}
+	setCondition(maasPlatform, myappv1alpha1.ConditionChangesPending, status, planReason(plan), plan.Summary)
+	maasPlatform.Status.ObservedGeneration = maasPlatform.Generation
+	if err := r.Status().Update(ctx, maasPlatform); err != nil {
+		log.Error(err, "Failed to update status")
+		return ctrl.Result{}, err
+	}
+	recordPlanEvent(r.Recorder, maasPlatform, previous, plan)
+
+	return ctrl.Result{RequeueAfter: planRequeueInterval}, nil
+}
+
+// planObjects plans the apply of rendered objects of the platform, the way applyObjects applies them
+func (r *MaasPlatformReconciler) planObjects(ctx context.Context, p *planner, objects []*unstructured.Unstructured, maasPlatform *myappv1alpha1.MaasPlatform) error {
+	for _, obj := range objects {
+		// Adopted and disabled optional components are never written
+		if component, ok := render.OptionalComponent(obj); ok && maasPlatform.Spec.ComponentMode(component) != myappv1alpha1.ComponentModeCreate {
+			continue
+		}
+
+		// Existing PVCs are only expanded
+		if obj.GetKind() == "PersistentVolumeClaim" {
+			if err := p.expandVolume(ctx, obj); err != nil {
+				return err
+			}
+			continue
+		}
+
+		r.setOwnerReference(ctx, obj, maasPlatform)
+		if err := p.apply(ctx, obj, forceConflicts(maasPlatform)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// planner compares desired objects with the cluster and collects the changes applying them
+// would make. Nothing is written: updates are computed with server-side apply dry runs.
+type planner struct {
+	client  client.Client
+	changes []myappv1alpha1.PlannedChange
+}
+
+// newPlanner returns a planner reading the cluster through the given client
+func newPlanner(c client.Client) *planner {
+	return &planner{client: c}
+}
+
+// apply plans the server-side apply of an object. With force, fields owned by other
+// managers are taken over, otherwise the object is reported as a conflict.
+func (p *planner) apply(ctx context.Context, obj *unstructured.Unstructured, force bool) error {
+	live, err := p.get(ctx, obj)
+	if meta.IsNoMatchError(err) {
+		p.add(obj, myappv1alpha1.PlannedActionCreate, nil, fmt.Sprintf("The %s kind is not installed yet", obj.GetKind()))
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if live == nil {
+		p.add(obj, myappv1alpha1.PlannedActionCreate, nil, "")
+		return nil
+	}
+
+	applied, err := p.dryRunApply(ctx, obj, false)
+	message := ""
+	if errors.IsConflict(err) {
+		if !force {
+			p.add(obj, myappv1alpha1.PlannedActionConflict, nil, err.Error())
+			return nil
+		}
+		message = fmt.Sprintf("Takes over fields from other field managers: %s", err.Error())
+		applied, err = p.dryRunApply(ctx, obj, true)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to plan %s %s: %w", obj.GetKind(), objectKeyString(obj), err)
+	}
+
+	if fields := changedFields(live.Object, applied.Object); len(fields) > 0 {
+		p.add(obj, myappv1alpha1.PlannedActionUpdate, fields, message)
+	}
+	return nil
+}
+
+// expandVolume plans growing an existing PVC, the only change made to PVCs that already exist
+func (p *planner) expandVolume(ctx context.Context, obj *unstructured.Unstructured) error {
+	live, err := p.get(ctx, obj)
+	if err != nil {
+		return err
+	}
+	if live == nil {
+		p.add(obj, myappv1alpha1.PlannedActionCreate, nil, "")
+		return nil
+	}
+
+	desiredSize, err := nestedQuantity(obj, "spec", "resources", "requests", "storage")
+	if err != nil || desiredSize == nil {
+		return err
+	}
+	currentSize, err := nestedQuantity(live, "spec", "resources", "requests", "storage")
+	if err != nil {
+		return err
+	}
+	if currentSize == nil || desiredSize.Cmp(*currentSize) > 0 {
+		p.add(obj, myappv1alpha1.PlannedActionUpdate, []string{"spec.resources.requests.storage"},
+			fmt.Sprintf("Expands the volume to %s", desiredSize.String()))
+	}
+	return nil
+}
+
+// delete plans the deletion of an object, if it exists
+func (p *planner) delete(ctx context.Context, obj *unstructured.Unstructured) error {
+	live, err := p.get(ctx, obj)
+	if meta.IsNoMatchError(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if live != nil {
+		p.add(obj, myappv1alpha1.PlannedActionDelete, nil, "")
+	}
+	return nil
+}
+
+// deleteAll plans the deletion of the objects that exist
+func (p *planner) deleteAll(ctx context.Context, objects []*unstructured.Unstructured) error {
+	for _, obj := range objects {
+		if err := p.delete(ctx, obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// plan returns the collected changes
+func (p *planner) plan() *myappv1alpha1.Plan {
+	return &myappv1alpha1.Plan{Summary: planSummary(p.changes), Changes: p.changes}
+}
+
+// get returns the live object, nil when it does not exist
+func (p *planner) get(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(obj.GroupVersionKind())
+	err := p.client.Get(ctx, client.ObjectKeyFromObject(obj), live)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get %s %s: %w", obj.GetKind(), objectKeyString(obj), err)
+	}
+	return live, nil
+}
+
+// dryRunApply returns the object the API server would store after applying the desired one
+func (p *planner) dryRunApply(ctx context.Context, obj *unstructured.Unstructured, force bool) (*unstructured.Unstructured, error) {
+	applied := obj.DeepCopy()
+	applied.SetResourceVersion("")
+	applied.SetManagedFields(nil)
+
+	opts := []client.PatchOption{client.FieldOwner(fieldManager), client.DryRunAll}
+	if force {
+		opts = append(opts, client.ForceOwnership)
+	}
+	if err := p.client.Patch(ctx, applied, client.Apply, opts...); err != nil {
+		return nil, err
+	}
+	return applied, nil
+}
+
+// add records a planned change
+func (p *planner) add(obj *unstructured.Unstructured, action string, fields []string, message string) {
+	p.changes = append(p.changes, myappv1alpha1.PlannedChange{
+		ManagedResource: myappv1alpha1.ManagedResource{
+			APIVersion: obj.GetAPIVersion(),
+			Kind:       obj.GetKind(),
+			Namespace:  obj.GetNamespace(),
+			Name:       obj.GetName(),
+		},
+		Action:  action,
+		Fields:  fields,
+		Message: message,
+	})
+}
+
+// changedFields returns the sorted paths of the fields that differ between the live object
+// and the one an apply would store. Lists are compared as a whole.
+func changedFields(live, applied map[string]interface{}) []string {
+	live = runtime.DeepCopyJSON(live)
+	applied = runtime.DeepCopyJSON(applied)
+	for _, fields := range ignoredPlanFields {
+		unstructured.RemoveNestedField(live, fields...)
+		unstructured.RemoveNestedField(applied, fields...)
+	}
+
+	var changed []string
+	diffFields("", live, applied, &changed)
+	sort.Strings(changed)
+	return changed
+}
+
+// diffFields appends the paths below prefix whose values differ
+func diffFields(prefix string, before, after map[string]interface{}, changed *[]string) {
+	keys := make(map[string]bool)
+	for key := range before {
+		keys[key] = true
+	}
+	for key := range after {
+		keys[key] = true
+	}
+
+	for key := range keys {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		beforeMap, beforeIsMap := before[key].(map[string]interface{})
+		afterMap, afterIsMap := after[key].(map[string]interface{})
+		if beforeIsMap && afterIsMap {
+			diffFields(path, beforeMap, afterMap, changed)
+			continue
+		}
+		if !equality.Semantic.DeepEqual(before[key], after[key]) {
+			*changed = append(*changed, path)
+		}
+	}
+}
+
+// planSummary counts the planned changes by action
+func planSummary(changes []myappv1alpha1.PlannedChange) string {
+	if len(changes) == 0 {
+		return "No changes"
+	}
+
+	counts := make(map[string]int)
+	for _, change := range changes {
+		counts[change.Action]++
+	}
+	summary := fmt.Sprintf("%d to create, %d to update, %d to delete",
+		counts[myappv1alpha1.PlannedActionCreate], counts[myappv1alpha1.PlannedActionUpdate], counts[myappv1alpha1.PlannedActionDelete])
+	if conflicts := counts[myappv1alpha1.PlannedActionConflict]; conflicts > 0 {
+		summary += fmt.Sprintf(", %d left alone because of conflicts", conflicts)
+	}
+	return summary
+}
+
+// planMessage describes a plan in a single line for Events and conditions, truncated to a sensible size
+func planMessage(plan *myappv1alpha1.Plan) string {
+	var items []string
+	for _, change := range plan.Changes {
+		key := change.Name
+		if change.Namespace != "" {
+			key = change.Namespace + "/" + change.Name
+		}
+		items = append(items, fmt.Sprintf("%s %s %s", change.Action, change.Kind, key))
+	}
+
+	message := plan.Summary
+	if len(items) > 0 {
+		message += ": " + strings.Join(items, ", ")
+	}
+	if len(message) > maxEventMessage {
+		message = message[:maxEventMessage-3] + "..."
+	}
+	return message
+}
+
+// planReason is the Event and condition reason for a plan
+func planReason(plan *myappv1alpha1.Plan) string {
+	if len(plan.Changes) == 0 {
+		return "NoChanges"
+	}
+	return "ChangesPending"
+}
+
+// recordPlanEvent publishes a plan as an Event on an object, unless it is the plan already published
+func recordPlanEvent(recorder record.EventRecorder, obj runtime.Object, previous, plan *myappv1alpha1.Plan) {
+	if recorder == nil || equality.Semantic.DeepEqual(previous, plan) {
+		return
+	}
+	recorder.Event(obj, corev1.EventTypeNormal, planReason(plan), planMessage(plan))
+}
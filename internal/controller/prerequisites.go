@@ -0,0 +1,147 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+	"github.com/jland-redhat/maas-operator.git/pkg/render"
+)
+
+// prerequisitesRequeueInterval is how often a platform with missing prerequisites is checked again.
+// The CRD watch usually notices the installation first.
+const prerequisitesRequeueInterval = 2 * time.Minute
+
+var (
+	gatewayClassGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "GatewayClass"}
+	kuadrantGVK     = schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1beta1", Kind: "Kuadrant"}
+	crdGVK          = schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"}
+)
+
+// prerequisite is an API the platform relies on, with the project that provides it
+type prerequisite struct {
+	Project string
+	GVK     schema.GroupVersionKind
+}
+
+// prerequisites are the APIs that must be served before a platform can be deployed
+var prerequisites = []prerequisite{
+	{Project: "Gateway API", GVK: gatewayClassGVK},
+	{Project: "Gateway API", GVK: gatewayGVK},
+	{Project: "Gateway API", GVK: httpRouteGVK},
+	{Project: "Kuadrant", GVK: kuadrantGVK},
+	{Project: "Kuadrant", GVK: authPolicyGVK},
+	{Project: "Kuadrant", GVK: render.RateLimitPolicyGVK},
+	{Project: "Kuadrant", GVK: render.TokenRateLimitPolicyGVK},
+	{Project: "KServe", GVK: render.LLMInferenceServiceGVK},
+}
+
+// prerequisitesMissingError lists the prerequisite APIs the cluster does not serve
+type prerequisitesMissingError struct {
+	missing []string
+}
+
+func (e *prerequisitesMissingError) Error() string {
+	return fmt.Sprintf("required APIs are not installed: %s", strings.Join(e.missing, ", "))
+}
+
+// missingPrerequisites asks the API server which prerequisite kinds are not served.
+// Discovery is queried on every call so newly installed CRDs are picked up right away.
+func missingPrerequisites(discoveryClient discovery.DiscoveryInterface) ([]string, error) {
+	served := make(map[schema.GroupVersion]map[string]bool)
+	var missing []string
+	for _, p := range prerequisites {
+		gv := p.GVK.GroupVersion()
+		kinds, ok := served[gv]
+		if !ok {
+			kinds = make(map[string]bool)
+			resources, err := discoveryClient.ServerResourcesForGroupVersion(gv.String())
+			if err != nil && !errors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to discover %s: %w", gv, err)
+			}
+			if resources != nil {
+				for _, resource := range resources.APIResources {
+					kinds[resource.Kind] = true
+				}
+			}
+			served[gv] = kinds
+		}
+		if !kinds[p.GVK.Kind] {
+			missing = append(missing, fmt.Sprintf("%s (%s %s)", p.Project, gv, p.GVK.Kind))
+		}
+	}
+	return missing, nil
+}
+
+// checkPrerequisites records the PrerequisitesMissing condition and returns a
+// prerequisitesMissingError when some of the APIs are not served.
+// Without a discovery client the check is skipped.
+func (r *MaasPlatformReconciler) checkPrerequisites(maasPlatform *myappv1alpha1.MaasPlatform) error {
+	if r.Discovery == nil {
+		return nil
+	}
+
+	missing, err := missingPrerequisites(r.Discovery)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		missingErr := &prerequisitesMissingError{missing: missing}
+		setCondition(maasPlatform, myappv1alpha1.ConditionPrerequisitesMissing, metav1.ConditionTrue, "PrerequisitesMissing", missingErr.Error())
+		return missingErr
+	}
+	setCondition(maasPlatform, myappv1alpha1.ConditionPrerequisitesMissing, metav1.ConditionFalse, "PrerequisitesInstalled", "All required APIs are served")
+	return nil
+}
+
+// prerequisiteCRD only lets through CRDs of the prerequisite API groups
+var prerequisiteCRD = predicate.NewPredicateFuncs(func(obj client.Object) bool {
+	for _, p := range prerequisites {
+		if strings.HasSuffix(obj.GetName(), "."+p.GVK.Group) {
+			return true
+		}
+	}
+	return false
+})
+
+// platformsForCRD maps a prerequisite CRD to every MaasPlatform, so platforms waiting for it resume
+func (r *MaasPlatformReconciler) platformsForCRD(ctx context.Context, _ client.Object) []reconcile.Request {
+	platformList := &myappv1alpha1.MaasPlatformList{}
+	if err := r.List(ctx, platformList); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list MaasPlatforms")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(platformList.Items))
+	for _, platform := range platformList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&platform)})
+	}
+	return requests
+}
@@ -19,26 +19,46 @@ package controller
 import (
 	"context"
 	"fmt"
-	"sort"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+	"github.com/jland-redhat/maas-operator.git/pkg/render"
 )
 
+// tierFinalizer makes sure the limits of a deleted Tier are removed from the policies
+const tierFinalizer = "myapp.io.odh.maas/tier-finalizer"
+
+// platformRequestPrefix marks the requests made for the Tiers of a MaasPlatform rather than for
+// one Tier. Object names cannot contain a slash, so these never collide with a Tier.
+const platformRequestPrefix = "maasplatform/"
+
 // TierReconciler reconciles a Tier object
 type TierReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// Recorder publishes the plans of the Plan management state as Events
+	Recorder record.EventRecorder
+	// PlanOnly plans the tier changes of every MaasPlatform, whatever its management state
+	PlanOnly bool
 }
 
 // +kubebuilder:rbac:groups=myapp.io.odh.maas,resources=tiers,verbs=get;list;watch;create;update;patch;delete
@@ -47,6 +67,8 @@ type TierReconciler struct {
 // +kubebuilder:rbac:groups=myapp.io.odh.maas,resources=maasplatforms,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=kuadrant.io,resources=ratelimitpolicies;tokenratelimitpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=llminferenceservices,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state specified by
@@ -54,6 +76,10 @@ type TierReconciler struct {
 func (r *TierReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
 
+	if name, ok := strings.CutPrefix(req.Name, platformRequestPrefix); ok {
+		return r.reconcilePlatformRequest(ctx, client.ObjectKey{Namespace: req.Namespace, Name: name})
+	}
+
 	// Fetch the Tier instance
 	tier := &myappv1alpha1.Tier{}
 	if err := r.Get(ctx, req.NamespacedName, tier); err != nil {
@@ -67,12 +93,22 @@ func (r *TierReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		return ctrl.Result{}, err
 	}
 
-	// Get the target MaasPlatform
-	maasPlatformNamespace := tier.Spec.TargetRef.Namespace
-	if maasPlatformNamespace == "" {
-		maasPlatformNamespace = tier.Namespace
+	// Remove the limits of the Tier before letting it go
+	if !tier.DeletionTimestamp.IsZero() {
+		return r.finalizeTier(ctx, tier)
 	}
 
+	if !controllerutil.ContainsFinalizer(tier, tierFinalizer) {
+		controllerutil.AddFinalizer(tier, tierFinalizer)
+		if err := r.Update(ctx, tier); err != nil {
+			log.Error(err, "Failed to add finalizer")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Get the target MaasPlatform
+	maasPlatformNamespace := tier.TargetNamespace()
+
 	maasPlatform := &myappv1alpha1.MaasPlatform{}
 	maasPlatformKey := client.ObjectKey{
 		Name:      tier.Spec.TargetRef.Name,
@@ -80,6 +116,19 @@ func (r *TierReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 	}
 
 	if err := r.Get(ctx, maasPlatformKey, maasPlatform); err != nil {
+		if errors.IsNotFound(err) {
+			// Not an error for the operator, but the tenant needs to know the limits are not live
+			log.Info("Target MaasPlatform not found", "name", tier.Spec.TargetRef.Name, "namespace", maasPlatformNamespace)
+			message := fmt.Sprintf("MaasPlatform %s/%s not found", maasPlatformNamespace, tier.Spec.TargetRef.Name)
+			tier.Status.Platform = ""
+			tier.Status.Limits = nil
+			setTierCondition(tier, myappv1alpha1.TierConditionTargetResolved, metav1.ConditionFalse, "PlatformNotFound", message)
+			setTierCondition(tier, myappv1alpha1.TierConditionPolicyProgrammed, metav1.ConditionFalse, "PlatformNotFound", message)
+			if err := r.updateTierStatus(ctx, tier, tier.Spec.Validate()); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
 		log.Error(err, "Failed to get target MaasPlatform", "name", tier.Spec.TargetRef.Name, "namespace", maasPlatformNamespace)
 		return ctrl.Result{}, err
 	}
@@ -88,6 +137,55 @@ func (r *TierReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 	return r.reconcileMaasPlatformTiers(ctx, maasPlatform)
 }
 
+// reconcilePlatformRequest reconciles the Tiers of a MaasPlatform, whether it has any or not
+func (r *TierReconciler) reconcilePlatformRequest(ctx context.Context, key client.ObjectKey) (ctrl.Result, error) {
+	maasPlatform := &myappv1alpha1.MaasPlatform{}
+	if err := r.Get(ctx, key, maasPlatform); err != nil {
+		if errors.IsNotFound(err) {
+			// The MaasPlatform finalizer has removed the tier objects
+			return ctrl.Result{}, nil
+		}
+		logf.FromContext(ctx).Error(err, "Failed to get MaasPlatform")
+		return ctrl.Result{}, err
+	}
+	return r.reconcileMaasPlatformTiers(ctx, maasPlatform)
+}
+
+// finalizeTier re-renders the platform policies without a deleted Tier and releases its finalizer
+func (r *TierReconciler) finalizeTier(ctx context.Context, tier *myappv1alpha1.Tier) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	if !controllerutil.ContainsFinalizer(tier, tierFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	maasPlatform := &myappv1alpha1.MaasPlatform{}
+	err := r.Get(ctx, client.ObjectKey{Name: tier.Spec.TargetRef.Name, Namespace: tier.TargetNamespace()}, maasPlatform)
+	if err == nil {
+		// Deleting Tiers are left out, so this drops the limits of the Tier
+		result, err := r.reconcileMaasPlatformTiers(ctx, maasPlatform)
+		if err != nil {
+			log.Error(err, "Failed to remove Tier limits")
+			return ctrl.Result{}, err
+		}
+		// The policies could not be written yet, the finalizer stays until the limits are gone
+		if !result.IsZero() {
+			log.Info("Keeping the Tier until its limits are removed")
+			return result, nil
+		}
+	} else if !errors.IsNotFound(err) {
+		log.Error(err, "Failed to get target MaasPlatform")
+		return ctrl.Result{}, err
+	}
+
+	controllerutil.RemoveFinalizer(tier, tierFinalizer)
+	if err := r.Update(ctx, tier); err != nil {
+		log.Error(err, "Failed to remove finalizer")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
 // reconcileAllMaasPlatforms reconciles all MaasPlatforms (used when Tier is deleted)
 func (r *TierReconciler) reconcileAllMaasPlatforms(ctx context.Context) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
@@ -112,6 +210,12 @@ func (r *TierReconciler) reconcileAllMaasPlatforms(ctx context.Context) (ctrl.Re
 func (r *TierReconciler) reconcileMaasPlatformTiers(ctx context.Context, maasPlatform *myappv1alpha1.MaasPlatform) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
 
+	// The MaasPlatform finalizer removes the policies, don't recreate them
+	if !maasPlatform.DeletionTimestamp.IsZero() {
+		log.Info("MaasPlatform is being deleted, skipping Tier reconciliation")
+		return ctrl.Result{}, nil
+	}
+
 	// List all Tiers
 	tierList := &myappv1alpha1.TierList{}
 	if err := r.List(ctx, tierList); err != nil {
@@ -119,320 +223,442 @@ func (r *TierReconciler) reconcileMaasPlatformTiers(ctx context.Context, maasPla
 		return ctrl.Result{}, err
 	}
 
-	// Filter Tiers targeting this MaasPlatform
-	var targetTiers []myappv1alpha1.Tier
-	for _, tier := range tierList.Items {
-		tierNamespace := tier.Spec.TargetRef.Namespace
-		if tierNamespace == "" {
-			tierNamespace = tier.Namespace
+	// Filter Tiers targeting this MaasPlatform, leaving out Tiers with an invalid spec
+	targetTiers, rejectedTiers := render.PlatformTiers(tierList.Items, maasPlatform)
+	for _, tier := range rejectedTiers {
+		log.Info("Rejecting invalid Tier", "tier", tier.Name, "namespace", tier.Namespace, "reason", tier.Spec.Validate().Error())
+	}
+
+	platform := fmt.Sprintf("%s/%s", maasPlatform.Namespace, maasPlatform.Name)
+	for i := range rejectedTiers {
+		tier := &rejectedTiers[i]
+		tier.Status.Platform = platform
+		tier.Status.Limits = nil
+		clearTierPlan(tier)
+		setTierCondition(tier, myappv1alpha1.TierConditionTargetResolved, metav1.ConditionTrue, "PlatformFound", fmt.Sprintf("MaasPlatform %s found", platform))
+		setTierCondition(tier, myappv1alpha1.TierConditionPolicyProgrammed, metav1.ConditionFalse, "Rejected", "Tier was not accepted")
+		if err := r.updateTierStatus(ctx, tier, tier.Spec.Validate()); err != nil {
+			log.Error(err, "Failed to update Tier status", "tier", tier.Name)
 		}
+	}
 
-		if tier.Spec.TargetRef.Name == maasPlatform.Name && tierNamespace == maasPlatform.Namespace {
-			targetTiers = append(targetTiers, tier)
+	// Suspended platforms and unmanaged tier policies are left as they are, hand edits included
+	state := maasPlatform.Spec.ComponentManagementState(myappv1alpha1.ComponentTierPolicies)
+	if reason, message := suspension(maasPlatform); reason != "" || state == myappv1alpha1.ManagementStateUnmanaged {
+		if reason == "" {
+			reason, message = myappv1alpha1.ManagementStateUnmanaged, "The tier policies are not managed by the operator"
 		}
+		log.Info("Tier reconciliation suspended", "reason", reason)
+		r.reportTierStatuses(ctx, targetTiers, maasPlatform, myappv1alpha1.TierConditionSuspended, metav1.ConditionTrue, reason, message)
+		return ctrl.Result{}, nil
 	}
 
-	if len(targetTiers) == 0 {
-		log.Info("No Tiers found targeting this MaasPlatform")
+	// Compare the tier objects with the cluster without writing them
+	if planOnly(r.PlanOnly, maasPlatform) {
+		return r.planTierObjects(ctx, targetTiers, maasPlatform)
+	}
+
+	if state == myappv1alpha1.ManagementStateRemoved {
+		log.Info("Removing tier resources as requested by the management state")
+		if err := r.deleteTierObjects(ctx, maasPlatform); err != nil {
+			log.Error(err, "Failed to remove tier resources")
+			r.updateTierStatuses(ctx, targetTiers, maasPlatform, err)
+			return ctrl.Result{}, err
+		}
+		for i := range targetTiers {
+			targetTiers[i].Status.Limits = nil
+			clearTierPlan(&targetTiers[i])
+			meta.RemoveStatusCondition(&targetTiers[i].Status.Conditions, myappv1alpha1.TierConditionSuspended)
+		}
+		r.reportTierStatuses(ctx, targetTiers, maasPlatform, myappv1alpha1.TierConditionPolicyProgrammed, metav1.ConditionFalse,
+			myappv1alpha1.ManagementStateRemoved, "The tier policies are removed, as requested by the management state of the MaasPlatform")
 		return ctrl.Result{}, nil
 	}
 
-	// Update ConfigMap with tier mappings
-	if err := r.updateTierConfigMap(ctx, targetTiers, maasPlatform); err != nil {
-		log.Error(err, "Failed to update tier ConfigMap")
-		return ctrl.Result{}, err
+	// Policies attach to the gateway and maas-api, which the platform deploys in the earlier phases
+	writesPolicies := len(targetTiers) > 0 || render.TierCleanupPolicy(maasPlatform) == myappv1alpha1.TierCleanupReset
+	if writesPolicies && !policiesPhaseReached(maasPlatform) {
+		message := fmt.Sprintf("Waiting for MaasPlatform %s to reach the %s phase", platform, myappv1alpha1.DeploymentPhasePolicies)
+		if maasPlatform.Status.Phase != "" {
+			message += fmt.Sprintf(", it is in the %s phase", maasPlatform.Status.Phase)
+		}
+		log.Info("Waiting for the MaasPlatform deployment", "phase", maasPlatform.Status.Phase)
+		r.reportTierStatuses(ctx, targetTiers, maasPlatform, myappv1alpha1.TierConditionPolicyProgrammed, metav1.ConditionFalse,
+			"WaitingForPlatform", message)
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
 	}
 
-	// Update RateLimitPolicy
-	if err := r.updateRateLimitPolicy(ctx, targetTiers, maasPlatform); err != nil {
-		log.Error(err, "Failed to update RateLimitPolicy")
-		return ctrl.Result{}, err
+	if len(targetTiers) == 0 {
+		log.Info("No Tiers found targeting this MaasPlatform")
+		if err := r.cleanupTierResources(ctx, maasPlatform); err != nil {
+			log.Error(err, "Failed to clean up tier resources")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
 	}
 
-	// Update TokenRateLimitPolicy
-	if err := r.updateTokenRateLimitPolicy(ctx, targetTiers, maasPlatform); err != nil {
-		log.Error(err, "Failed to update TokenRateLimitPolicy")
+	// Apply conflicts are reported on the Tiers instead of failing the whole reconcile
+	conflicts, err := r.applyTierObjects(ctx, targetTiers, maasPlatform)
+	if meta.IsNoMatchError(err) {
+		return r.waitForPolicyCRD(ctx, targetTiers, maasPlatform, err)
+	} else if err != nil {
+		log.Error(err, "Failed to apply tier resources")
+		r.updateTierStatuses(ctx, targetTiers, maasPlatform, err)
 		return ctrl.Result{}, err
 	}
 
+	if len(conflicts) > 0 {
+		log.Info("Field conflicts, leaving the conflicting resources as they are", "conflicts", conflicts)
+		r.updateTierStatuses(ctx, targetTiers, maasPlatform, &applyConflictError{conflicts: conflicts})
+		return ctrl.Result{}, nil
+	}
+
+	r.updateTierStatuses(ctx, targetTiers, maasPlatform, nil)
 	return ctrl.Result{}, nil
 }
 
-// updateTierConfigMap updates the tier-to-group-mapping ConfigMap
-func (r *TierReconciler) updateTierConfigMap(ctx context.Context, tiers []myappv1alpha1.Tier, maasPlatform *myappv1alpha1.MaasPlatform) error {
-	log := logf.FromContext(ctx)
-
-	configMapName := "tier-to-group-mapping"
-	configMapNamespace := "maas-api" // Default namespace for maas-api
+// waitForPolicyCRD reports a policy kind that is not installed on the Tiers and checks again later
+// rather than failing, since retrying quickly cannot help until Kuadrant is installed
+func (r *TierReconciler) waitForPolicyCRD(ctx context.Context, tiers []myappv1alpha1.Tier, maasPlatform *myappv1alpha1.MaasPlatform, err error) (ctrl.Result, error) {
+	logf.FromContext(ctx).Info("Policy kind not installed, waiting for Kuadrant", "reason", err.Error())
+	r.updateTierStatuses(ctx, tiers, maasPlatform, err)
+	return ctrl.Result{RequeueAfter: prerequisitesRequeueInterval}, nil
+}
 
-	configMap := &corev1.ConfigMap{}
-	key := client.ObjectKey{
-		Name:      configMapName,
-		Namespace: configMapNamespace,
+// cleanupTierResources deletes or resets the tier ConfigMap and policies once a platform has no Tiers
+func (r *TierReconciler) cleanupTierResources(ctx context.Context, maasPlatform *myappv1alpha1.MaasPlatform) error {
+	if render.TierCleanupPolicy(maasPlatform) == myappv1alpha1.TierCleanupReset {
+		conflicts, err := r.applyTierObjects(ctx, nil, maasPlatform)
+		if err != nil {
+			return err
+		}
+		if len(conflicts) > 0 {
+			return &applyConflictError{conflicts: conflicts}
+		}
+		return nil
 	}
+	return r.deleteTierObjects(ctx, maasPlatform)
+}
 
-	err := r.Get(ctx, key, configMap)
-	if errors.IsNotFound(err) {
-		// Create new ConfigMap
-		configMap = &corev1.ConfigMap{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      configMapName,
-				Namespace: configMapNamespace,
-			},
+// deleteTierObjects deletes the tier ConfigMap and policies of a platform
+func (r *TierReconciler) deleteTierObjects(ctx context.Context, maasPlatform *myappv1alpha1.MaasPlatform) error {
+	log := logf.FromContext(ctx)
+
+	for _, obj := range tierObjects(maasPlatform) {
+		err := r.Delete(ctx, obj)
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to delete %s: %w", obj.GetKind(), err)
 		}
-	} else if err != nil {
-		return fmt.Errorf("failed to get ConfigMap: %w", err)
+		log.Info("Deleted tier resource", "kind", obj.GetKind(), "name", obj.GetName(), "namespace", obj.GetNamespace())
 	}
+	return nil
+}
 
-	// Build tier mapping YAML
-	var tierMappings strings.Builder
-	tierMappings.WriteString("tiers: |\n")
-
-	// Sort tiers by name for consistent output
-	sortedTiers := make([]myappv1alpha1.Tier, len(tiers))
-	copy(sortedTiers, tiers)
-	sort.Slice(sortedTiers, func(i, j int) bool {
-		return sortedTiers[i].Name < sortedTiers[j].Name
-	})
+// tierObjects returns references to the tier ConfigMap and policies of a platform
+func tierObjects(maasPlatform *myappv1alpha1.MaasPlatform) []*unstructured.Unstructured {
+	names := render.NamesFor(maasPlatform)
 
-	for _, tier := range sortedTiers {
-		tierName := tier.Name
-		if tierName == "" {
-			tierName = tier.GetGenerateName() + "-tier"
-		}
+	configMap := newUnstructured(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+	configMap.SetName(names.TierConfigMap)
+	configMap.SetNamespace(maasPlatform.Spec.APINamespace())
 
-		// Extract level from tier name (simplified - could be enhanced)
-		level := 0
-		if strings.Contains(strings.ToLower(tierName), "premium") {
-			level = 1
-		} else if strings.Contains(strings.ToLower(tierName), "enterprise") {
-			level = 2
-		}
+	rateLimitPolicy := newUnstructured(render.RateLimitPolicyGVK)
+	rateLimitPolicy.SetName(names.RateLimitPolicy)
+	rateLimitPolicy.SetNamespace(maasPlatform.Spec.GatewayNamespace())
 
-		tierMappings.WriteString(fmt.Sprintf("  # Tier: %s\n", tierName))
-		tierMappings.WriteString(fmt.Sprintf("  - name: %s\n", tierName))
-		tierMappings.WriteString(fmt.Sprintf("    level: %d\n", level))
-		tierMappings.WriteString("    groups:\n")
-		// Use tier name as group (simplified - in production this should map to actual user groups)
-		tierMappings.WriteString(fmt.Sprintf("      - tier-%s-users\n", tierName))
-		tierMappings.WriteString("      - system:authenticated\n")
-		tierMappings.WriteString("\n")
-	}
+	tokenRateLimitPolicy := newUnstructured(render.TokenRateLimitPolicyGVK)
+	tokenRateLimitPolicy.SetName(names.TokenRateLimitPolicy)
+	tokenRateLimitPolicy.SetNamespace(maasPlatform.Spec.GatewayNamespace())
 
-	configMap.Data = map[string]string{
-		"tiers": tierMappings.String(),
-	}
+	return []*unstructured.Unstructured{configMap, rateLimitPolicy, tokenRateLimitPolicy}
+}
 
-	// Set owner reference to the first MaasPlatform
-	if len(tiers) > 0 {
-		// We can't set owner ref to multiple resources, so we'll use labels instead
-		if configMap.Labels == nil {
-			configMap.Labels = make(map[string]string)
-		}
-		configMap.Labels["app.kubernetes.io/managed-by"] = "maas-operator"
-		configMap.Labels["maas-platform"] = fmt.Sprintf("%s.%s", maasPlatform.Name, maasPlatform.Namespace)
-	}
+// planTierObjects plans the changes to the tier ConfigMap and policies of a platform, including
+// their cleanup once it has no Tiers, and publishes them on the accepted Tiers. Without Tiers
+// pending changes are published on the MaasPlatform instead.
+func (r *TierReconciler) planTierObjects(ctx context.Context, tiers []myappv1alpha1.Tier, maasPlatform *myappv1alpha1.MaasPlatform) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	p := newPlanner(r.Client)
 
-	if err := r.Get(ctx, key, configMap); errors.IsNotFound(err) {
-		if err := r.Create(ctx, configMap); err != nil {
-			return fmt.Errorf("failed to create ConfigMap: %w", err)
+	removed := maasPlatform.Spec.ComponentManagementState(myappv1alpha1.ComponentTierPolicies) == myappv1alpha1.ManagementStateRemoved
+	if removed || len(tiers) == 0 && render.TierCleanupPolicy(maasPlatform) != myappv1alpha1.TierCleanupReset {
+		if err := p.deleteAll(ctx, tierObjects(maasPlatform)); err != nil {
+			log.Error(err, "Failed to plan tier resource cleanup")
+			return ctrl.Result{}, err
 		}
-		log.Info("Created tier ConfigMap", "name", configMapName, "namespace", configMapNamespace)
 	} else {
-		if err := r.Update(ctx, configMap); err != nil {
-			return fmt.Errorf("failed to update ConfigMap: %w", err)
+		objects, err := r.renderTierObjects(ctx, tiers, maasPlatform)
+		if err != nil {
+			log.Error(err, "Failed to render tier resources")
+			r.updateTierStatuses(ctx, tiers, maasPlatform, err)
+			return ctrl.Result{}, err
+		}
+		for _, obj := range objects {
+			if err := p.apply(ctx, obj, forceConflicts(maasPlatform)); err != nil {
+				log.Error(err, "Failed to plan tier resources")
+				return ctrl.Result{}, err
+			}
 		}
-		log.Info("Updated tier ConfigMap", "name", configMapName, "namespace", configMapNamespace)
 	}
 
-	return nil
-}
-
-// updateRateLimitPolicy updates or creates the RateLimitPolicy
-func (r *TierReconciler) updateRateLimitPolicy(ctx context.Context, tiers []myappv1alpha1.Tier, maasPlatform *myappv1alpha1.MaasPlatform) error {
-	log := logf.FromContext(ctx)
-
-	policyName := "gateway-rate-limits"
-	policyNamespace := "openshift-ingress"
-
-	policy := &unstructured.Unstructured{}
-	policy.SetGroupVersionKind(schema.GroupVersionKind{
-		Group:   "kuadrant.io",
-		Version: "v1",
-		Kind:    "RateLimitPolicy",
-	})
-
-	key := client.ObjectKey{
-		Name:      policyName,
-		Namespace: policyNamespace,
+	plan := p.plan()
+	log.Info("Planned tier changes, nothing applied", "summary", plan.Summary)
+	if len(tiers) == 0 && len(plan.Changes) > 0 && r.Recorder != nil {
+		r.Recorder.Event(maasPlatform, corev1.EventTypeNormal, planReason(plan), planMessage(plan))
 	}
 
-	err := r.Get(ctx, key, policy)
-	if errors.IsNotFound(err) {
-		// Create new policy
-		policy.SetName(policyName)
-		policy.SetNamespace(policyNamespace)
-	} else if err != nil {
-		return fmt.Errorf("failed to get RateLimitPolicy: %w", err)
+	status := metav1.ConditionFalse
+	if len(plan.Changes) > 0 {
+		status = metav1.ConditionTrue
 	}
-
-	// Build limits from tiers
-	limits := make(map[string]interface{})
-	for _, tier := range tiers {
-		if tier.Spec.RateLimits == nil {
+	platform := fmt.Sprintf("%s/%s", maasPlatform.Namespace, maasPlatform.Name)
+	for i := range tiers {
+		tier := &tiers[i]
+		previous := tier.Status.Plan
+		tier.Status.Platform = platform
+		tier.Status.Plan = plan
+		meta.RemoveStatusCondition(&tier.Status.Conditions, myappv1alpha1.TierConditionSuspended)
+		setTierCondition(tier, myappv1alpha1.TierConditionTargetResolved, metav1.ConditionTrue, "PlatformFound", fmt.Sprintf("MaasPlatform %s found", platform))
+		setTierCondition(tier, myappv1alpha1.TierConditionChangesPending, status, planReason(plan), plan.Summary)
+		if err := r.updateTierStatus(ctx, tier, nil); err != nil {
+			log.Error(err, "Failed to update Tier status", "tier", tier.Name)
 			continue
 		}
+		recordPlanEvent(r.Recorder, tier, previous, plan)
+	}
 
-		tierName := tier.Name
-		if tierName == "" {
-			tierName = tier.GetGenerateName() + "-tier"
-		}
+	return ctrl.Result{RequeueAfter: planRequeueInterval}, nil
+}
 
-		tierLimit := map[string]interface{}{
-			"rates": []map[string]interface{}{
-				{
-					"limit":  tier.Spec.RateLimits.Limit,
-					"window": tier.Spec.RateLimits.Window,
-				},
-			},
-			"when": []map[string]interface{}{
-				{
-					"predicate": fmt.Sprintf(`auth.identity.tier == "%s"`, tierName),
-				},
-			},
+// reportTierStatuses sets a condition on the accepted Tiers of a platform and writes their status
+func (r *TierReconciler) reportTierStatuses(ctx context.Context, tiers []myappv1alpha1.Tier, maasPlatform *myappv1alpha1.MaasPlatform,
+	conditionType string, status metav1.ConditionStatus, reason, message string) {
+	platform := fmt.Sprintf("%s/%s", maasPlatform.Namespace, maasPlatform.Name)
+	for i := range tiers {
+		tier := &tiers[i]
+		tier.Status.Platform = platform
+		setTierCondition(tier, myappv1alpha1.TierConditionTargetResolved, metav1.ConditionTrue, "PlatformFound", fmt.Sprintf("MaasPlatform %s found", platform))
+		setTierCondition(tier, conditionType, status, reason, message)
+		if err := r.updateTierStatus(ctx, tier, nil); err != nil {
+			logf.FromContext(ctx).Error(err, "Failed to update Tier status", "tier", tier.Name)
 		}
+	}
+}
 
-		counters := tier.Spec.RateLimits.Counters
-		if len(counters) == 0 {
-			counters = []string{"auth.identity.userid"}
-		}
-		tierLimit["counters"] = counters
+// clearTierPlan drops the plan of a Tier whose platform is no longer in the Plan management state
+func clearTierPlan(tier *myappv1alpha1.Tier) {
+	tier.Status.Plan = nil
+	meta.RemoveStatusCondition(&tier.Status.Conditions, myappv1alpha1.TierConditionChangesPending)
+}
 
-		limits[tierName] = tierLimit
-	}
+// applyConflictError reports the policies that were not updated at all because of field conflicts
+type applyConflictError struct {
+	conflicts []string
+}
 
-	// Set spec
-	if err := unstructured.SetNestedMap(policy.Object, map[string]interface{}{
-		"targetRef": map[string]interface{}{
-			"group": "gateway.networking.k8s.io",
-			"kind":  "Gateway",
-			"name":  "maas-default-gateway",
-		},
-		"limits": limits,
-	}, "spec"); err != nil {
-		return fmt.Errorf("failed to set policy spec: %w", err)
-	}
+func (e *applyConflictError) Error() string {
+	return strings.Join(e.conflicts, "; ")
+}
+
+// updateTierStatuses records the outcome of programming the platform policies on every accepted Tier
+func (r *TierReconciler) updateTierStatuses(ctx context.Context, tiers []myappv1alpha1.Tier, maasPlatform *myappv1alpha1.MaasPlatform, policyErr error) {
+	log := logf.FromContext(ctx)
 
-	if err := r.Get(ctx, key, policy); errors.IsNotFound(err) {
-		if err := r.Create(ctx, policy); err != nil {
-			return fmt.Errorf("failed to create RateLimitPolicy: %w", err)
+	platform := fmt.Sprintf("%s/%s", maasPlatform.Namespace, maasPlatform.Name)
+
+	for i := range tiers {
+		tier := &tiers[i]
+		tier.Status.Platform = platform
+		clearTierPlan(tier)
+		meta.RemoveStatusCondition(&tier.Status.Conditions, myappv1alpha1.TierConditionSuspended)
+		setTierCondition(tier, myappv1alpha1.TierConditionTargetResolved, metav1.ConditionTrue, "PlatformFound", fmt.Sprintf("MaasPlatform %s found", platform))
+
+		if conflictErr, ok := policyErr.(*applyConflictError); ok {
+			setTierCondition(tier, myappv1alpha1.TierConditionPolicyProgrammed, metav1.ConditionFalse, "ApplyConflict", conflictErr.Error())
+		} else if meta.IsNoMatchError(policyErr) {
+			setTierCondition(tier, myappv1alpha1.TierConditionPolicyProgrammed, metav1.ConditionFalse, "PrerequisitesMissing", policyErr.Error())
+		} else if policyErr != nil {
+			setTierCondition(tier, myappv1alpha1.TierConditionPolicyProgrammed, metav1.ConditionFalse, "PolicyUpdateFailed", policyErr.Error())
+		} else {
+			tier.Status.Limits = render.TierLimitStatuses(tier, maasPlatform)
+			setTierCondition(tier, myappv1alpha1.TierConditionPolicyProgrammed, metav1.ConditionTrue, "PolicyProgrammed", "Tier limits written to the gateway policies")
 		}
-		log.Info("Created RateLimitPolicy", "name", policyName)
-	} else {
-		if err := r.Update(ctx, policy); err != nil {
-			return fmt.Errorf("failed to update RateLimitPolicy: %w", err)
+
+		if err := r.updateTierStatus(ctx, tier, nil); err != nil {
+			log.Error(err, "Failed to update Tier status", "tier", tier.Name)
 		}
-		log.Info("Updated RateLimitPolicy", "name", policyName)
 	}
+}
 
-	return nil
+// updateTierStatus sets the Accepted condition from the validation result and writes the Tier status
+func (r *TierReconciler) updateTierStatus(ctx context.Context, tier *myappv1alpha1.Tier, validationErr error) error {
+	if validationErr != nil {
+		setTierCondition(tier, myappv1alpha1.TierConditionAccepted, metav1.ConditionFalse, "InvalidSpec", validationErr.Error())
+	} else {
+		setTierCondition(tier, myappv1alpha1.TierConditionAccepted, metav1.ConditionTrue, "Accepted", "Tier spec is valid")
+	}
+	tier.Status.ObservedGeneration = tier.Generation
+
+	return r.Status().Update(ctx, tier)
+}
+
+// setTierCondition sets a condition on the Tier status for the current generation
+func setTierCondition(tier *myappv1alpha1.Tier, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&tier.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: tier.Generation,
+	})
 }
 
-// updateTokenRateLimitPolicy updates or creates the TokenRateLimitPolicy
-func (r *TierReconciler) updateTokenRateLimitPolicy(ctx context.Context, tiers []myappv1alpha1.Tier, maasPlatform *myappv1alpha1.MaasPlatform) error {
+// applyTierObjects renders the tier ConfigMap and policies of a platform from its accepted Tiers
+// and applies them. Field conflicts are returned rather than failing the apply.
+func (r *TierReconciler) applyTierObjects(ctx context.Context, tiers []myappv1alpha1.Tier, maasPlatform *myappv1alpha1.MaasPlatform) ([]string, error) {
 	log := logf.FromContext(ctx)
 
-	policyName := "gateway-token-rate-limits"
-	policyNamespace := "openshift-ingress"
+	objects, err := r.renderTierObjects(ctx, tiers, maasPlatform)
+	if err != nil {
+		return nil, err
+	}
 
-	policy := &unstructured.Unstructured{}
-	policy.SetGroupVersionKind(schema.GroupVersionKind{
-		Group:   "kuadrant.io",
-		Version: "v1alpha1",
-		Kind:    "TokenRateLimitPolicy",
-	})
+	var conflicts []string
+	for _, obj := range objects {
+		err := applyObject(ctx, r.Client, obj, forceConflicts(maasPlatform))
+		if errors.IsConflict(err) {
+			conflicts = append(conflicts, fmt.Sprintf("%s not updated: %s", obj.GetKind(), err.Error()))
+			continue
+		} else if err != nil {
+			return conflicts, fmt.Errorf("failed to apply %s: %w", obj.GetKind(), err)
+		}
+		log.Info("Applied tier resource", "kind", obj.GetKind(), "name", obj.GetName(), "namespace", obj.GetNamespace())
+	}
+	return conflicts, nil
+}
 
-	key := client.ObjectKey{
-		Name:      policyName,
-		Namespace: policyNamespace,
+// renderTierObjects renders the tier ConfigMap and policies of a platform from its accepted Tiers
+func (r *TierReconciler) renderTierObjects(ctx context.Context, tiers []myappv1alpha1.Tier, maasPlatform *myappv1alpha1.MaasPlatform) ([]*unstructured.Unstructured, error) {
+	models, err := r.listServedModels(ctx)
+	if err != nil {
+		return nil, err
 	}
+	return render.RenderTiers(tiers, maasPlatform, render.ClusterFacts{Models: models})
+}
 
-	err := r.Get(ctx, key, policy)
-	if errors.IsNotFound(err) {
-		// Create new policy
-		policy.SetName(policyName)
-		policy.SetNamespace(policyNamespace)
+// listServedModels returns the LLMInferenceServices model names resolve against.
+// Without KServe installed there is nothing to resolve against.
+func (r *TierReconciler) listServedModels(ctx context.Context) ([]render.Model, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(render.LLMInferenceServiceGVK.GroupVersion().WithKind(render.LLMInferenceServiceGVK.Kind + "List"))
+	err := r.List(ctx, list)
+	if meta.IsNoMatchError(err) {
+		return nil, nil
 	} else if err != nil {
-		return fmt.Errorf("failed to get TokenRateLimitPolicy: %w", err)
+		return nil, fmt.Errorf("failed to list LLMInferenceServices: %w", err)
 	}
 
-	// Build limits from tiers
-	limits := make(map[string]interface{})
-	for _, tier := range tiers {
-		if tier.Spec.TokenRateLimits == nil {
-			continue
-		}
+	return render.ModelsFrom(list.Items), nil
+}
 
-		tierName := tier.Name
-		if tierName == "" {
-			tierName = tier.GetGenerateName() + "-tier"
-		}
+// SetupWithManager sets up the controller with the Manager.
+// The generated ConfigMap and policies are watched so manual edits are reverted.
+func (r *TierReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&myappv1alpha1.Tier{}).
+		Named("tier")
 
-		limitName := fmt.Sprintf("%s-user-tokens", tierName)
-		tierLimit := map[string]interface{}{
-			"rates": []map[string]interface{}{
-				{
-					"limit":  tier.Spec.TokenRateLimits.Limit,
-					"window": tier.Spec.TokenRateLimits.Window,
-				},
-			},
-			"when": []map[string]interface{}{
-				{
-					"predicate": fmt.Sprintf(`auth.identity.tier == "%s"`, tierName),
-				},
-			},
-		}
+	w := newManagedWatches(mgr)
+	b = w.watchManaged(b, &corev1.ConfigMap{}, r.tiersForManagedObject, tierConfigMap)
+	b = w.watchManaged(b, newUnstructured(render.RateLimitPolicyGVK), r.tiersForManagedObject)
+	b = w.watchManaged(b, newUnstructured(render.TokenRateLimitPolicyGVK), r.tiersForManagedObject)
 
-		counters := tier.Spec.TokenRateLimits.Counters
-		if len(counters) == 0 {
-			counters = []string{"auth.identity.userid"}
-		}
-		tierLimit["counters"] = counters
+	// Suspending, resuming or removing a platform changes what happens to its tier policies,
+	// and the policies wait for the platform to reach the policies phase
+	b = b.Watches(&myappv1alpha1.MaasPlatform{}, handler.EnqueueRequestsFromMapFunc(r.tiersForPlatform),
+		builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}, platformPhaseChanged)))
+
+	// Model names resolve against LLMInferenceServices, so a new or renamed model re-renders the policies
+	b = w.watch(b, newUnstructured(render.LLMInferenceServiceGVK), handler.EnqueueRequestsFromMapFunc(r.tiersForServedModel),
+		predicate.GenerationChangedPredicate{})
+
+	return w.complete(b, r)
+}
 
-		limits[limitName] = tierLimit
+// tierConfigMap lets through the tier ConfigMaps, with derived or legacy names, among the
+// ConfigMaps of the platforms
+var tierConfigMap = predicate.NewPredicateFuncs(func(obj client.Object) bool {
+	return obj.GetName() == render.LegacyNames.TierConfigMap || strings.HasSuffix(obj.GetName(), "-"+render.TierConfigMapName)
+})
+
+// platformPhaseChanged lets through MaasPlatform updates that change the deployment phase
+var platformPhaseChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldPlatform, okOld := e.ObjectOld.(*myappv1alpha1.MaasPlatform)
+		newPlatform, okNew := e.ObjectNew.(*myappv1alpha1.MaasPlatform)
+		return okOld && okNew && oldPlatform.Status.Phase != newPlatform.Status.Phase
+	},
+}
+
+// policiesPhaseReached reports whether a MaasPlatform is deployed far enough for the tier policies.
+// Only the initial rollout holds them back: once the platform has applied its AuthPolicies they
+// are in its inventory, and a later phase that blocks again, maas-api restarting for instance,
+// does not hold back the Tier changes.
+func policiesPhaseReached(maasPlatform *myappv1alpha1.MaasPlatform) bool {
+	switch maasPlatform.Status.Phase {
+	case myappv1alpha1.DeploymentPhasePolicies, myappv1alpha1.DeploymentPhaseComplete:
+		return true
 	}
+	for _, resource := range maasPlatform.Status.ManagedResources {
+		if resource.Kind == "AuthPolicy" {
+			return true
+		}
+	}
+	return false
+}
 
-	// Set spec
-	if err := unstructured.SetNestedMap(policy.Object, map[string]interface{}{
-		"targetRef": map[string]interface{}{
-			"group": "gateway.networking.k8s.io",
-			"kind":  "Gateway",
-			"name":  "maas-default-gateway",
-		},
-		"limits": limits,
-	}, "spec"); err != nil {
-		return fmt.Errorf("failed to set policy spec: %w", err)
+// tiersForServedModel maps an LLMInferenceService to the MaasPlatforms with model-scoped Tiers
+func (r *TierReconciler) tiersForServedModel(ctx context.Context, _ client.Object) []reconcile.Request {
+	tierList := &myappv1alpha1.TierList{}
+	if err := r.List(ctx, tierList); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list Tiers")
+		return nil
 	}
 
-	if err := r.Get(ctx, key, policy); errors.IsNotFound(err) {
-		if err := r.Create(ctx, policy); err != nil {
-			return fmt.Errorf("failed to create TokenRateLimitPolicy: %w", err)
+	var requests []reconcile.Request
+	platforms := make(map[string]bool)
+	for _, tier := range tierList.Items {
+		if len(tier.Spec.Models) == 0 && len(tier.Spec.ModelLimits) == 0 {
+			continue
 		}
-		log.Info("Created TokenRateLimitPolicy", "name", policyName)
-	} else {
-		if err := r.Update(ctx, policy); err != nil {
-			return fmt.Errorf("failed to update TokenRateLimitPolicy: %w", err)
+		platform := fmt.Sprintf("%s/%s", tier.TargetNamespace(), tier.Spec.TargetRef.Name)
+		if platforms[platform] {
+			continue
 		}
-		log.Info("Updated TokenRateLimitPolicy", "name", policyName)
+		platforms[platform] = true
+		requests = append(requests, platformTiersRequest(client.ObjectKey{Namespace: tier.TargetNamespace(), Name: tier.Spec.TargetRef.Name}))
 	}
+	return requests
+}
 
-	return nil
+// tiersForManagedObject maps a generated object to the Tiers of its MaasPlatform
+func (r *TierReconciler) tiersForManagedObject(_ context.Context, obj client.Object) []reconcile.Request {
+	platform, ok := platformForObject(obj)
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{platformTiersRequest(platform)}
 }
 
-// SetupWithManager sets up the controller with the Manager.
-func (r *TierReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&myappv1alpha1.Tier{}).
-		Named("tier").
-		Complete(r)
+// tiersForPlatform maps a MaasPlatform to its Tiers
+func (r *TierReconciler) tiersForPlatform(_ context.Context, obj client.Object) []reconcile.Request {
+	return []reconcile.Request{platformTiersRequest(client.ObjectKeyFromObject(obj))}
+}
+
+// platformTiersRequest returns the request that reconciles every Tier of a MaasPlatform.
+// It does not name a Tier, so the tier objects of a platform without Tiers are kept up to date too.
+func platformTiersRequest(platform client.ObjectKey) reconcile.Request {
+	return reconcile.Request{NamespacedName: client.ObjectKey{Namespace: platform.Namespace, Name: platformRequestPrefix + platform.Name}}
 }
@@ -21,13 +21,20 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+	"github.com/jland-redhat/maas-operator.git/pkg/render"
 )
 
 var _ = Describe("Tier Controller", func() {
@@ -65,6 +72,17 @@ var _ = Describe("Tier Controller", func() {
 
 			By("Cleanup the specific resource instance Tier")
 			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			By("Reconciling the deletion to release the finalizer")
+			controllerReconciler := &TierReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, typeNamespacedName, resource))).To(BeTrue())
 		})
 		It("should successfully reconcile the resource", func() {
 			By("Reconciling the created resource")
@@ -80,5 +98,139 @@ var _ = Describe("Tier Controller", func() {
 			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
 			// Example: If you expect a certain status condition after reconciliation, verify it here.
 		})
+
+		It("should report an unresolved target in status", func() {
+			controllerReconciler := &TierReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).NotTo(BeZero())
+
+			resource := &myappv1alpha1.Tier{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.ObservedGeneration).To(Equal(resource.Generation))
+			Expect(meta.IsStatusConditionFalse(resource.Status.Conditions, myappv1alpha1.TierConditionTargetResolved)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(resource.Status.Conditions, myappv1alpha1.TierConditionPolicyProgrammed)).To(BeTrue())
+			Expect(resource.Finalizers).To(ContainElement(tierFinalizer))
+		})
+	})
+
+	Context("When validating a Tier spec", func() {
+		It("should reject unparsable windows and non-positive limits", func() {
+			tier := &myappv1alpha1.Tier{
+				Spec: myappv1alpha1.TierSpec{
+					RateLimits: &myappv1alpha1.TierRateLimitConfig{Limit: 10, Window: "2m"},
+				},
+			}
+			Expect(tier.Spec.Validate()).To(Succeed())
+
+			tier.Spec.RateLimits.Window = "two minutes"
+			Expect(tier.Spec.Validate()).NotTo(Succeed())
+
+			tier.Spec.RateLimits.Window = "2m"
+			tier.Spec.TokenRateLimits = &myappv1alpha1.TierTokenRateLimitConfig{Limit: 0, Window: "1m"}
+			Expect(tier.Spec.Validate()).NotTo(Succeed())
+		})
+
+		It("should require exactly one model reference per model limit", func() {
+			spec := myappv1alpha1.TierSpec{
+				ModelLimits: []myappv1alpha1.TierModelLimit{{
+					RateLimits: &myappv1alpha1.TierRateLimitConfig{Limit: 10, Window: "1m"},
+				}},
+			}
+			Expect(spec.Validate()).NotTo(Succeed())
+
+			spec.ModelLimits[0].Model = "small"
+			Expect(spec.Validate()).To(Succeed())
+
+			spec.ModelLimits[0].LLMInferenceServiceRef = &myappv1alpha1.LLMInferenceServiceRef{Name: "small"}
+			Expect(spec.Validate()).NotTo(Succeed())
+		})
+
+		It("should reject empty group names", func() {
+			tier := &myappv1alpha1.Tier{ObjectMeta: metav1.ObjectMeta{Name: "premium"}}
+			tier.Spec.Groups = []string{""}
+			Expect(tier.Spec.Validate()).NotTo(Succeed())
+		})
+
+		It("should reject empty user and ServiceAccount names", func() {
+			spec := myappv1alpha1.TierSpec{Users: []string{" "}}
+			Expect(spec.Validate()).NotTo(Succeed())
+
+			spec = myappv1alpha1.TierSpec{ServiceAccounts: []myappv1alpha1.ServiceAccountRef{{Namespace: "jobs"}}}
+			Expect(spec.Validate()).To(MatchError(ContainSubstring("serviceAccounts[0].name")))
+		})
+	})
+})
+
+var _ = Describe("Tier platform requests", func() {
+	It("should keep the tier objects of a platform without Tiers up to date", func() {
+		platform := &myappv1alpha1.MaasPlatform{ObjectMeta: metav1.ObjectMeta{Name: "maas-platform", Namespace: "maas-system"}}
+		configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name:      render.NamesFor(platform).TierConfigMap,
+			Namespace: platform.Spec.APINamespace(),
+		}}
+		reconciler := &TierReconciler{Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(platform, configMap).Build()}
+
+		requests := reconciler.tiersForPlatform(ctx, platform)
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0].Name).To(ContainSubstring("/"))
+
+		_, err := reconciler.Reconcile(ctx, requests[0])
+		Expect(err).NotTo(HaveOccurred())
+		err = reconciler.Get(ctx, client.ObjectKeyFromObject(configMap), &corev1.ConfigMap{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+
+		By("watching the tier ConfigMaps with derived and legacy names only")
+		Expect(tierConfigMap.Generic(event.GenericEvent{Object: configMap})).To(BeTrue())
+		legacy := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: render.LegacyNames.TierConfigMap}}
+		Expect(tierConfigMap.Generic(event.GenericEvent{Object: legacy})).To(BeTrue())
+		other := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "maas-platform-config"}}
+		Expect(tierConfigMap.Generic(event.GenericEvent{Object: other})).To(BeFalse())
+
+		By("ignoring a platform that is gone")
+		_, err = reconciler.Reconcile(ctx, platformTiersRequest(client.ObjectKey{Namespace: "maas-system", Name: "gone"}))
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
+
+var _ = Describe("Tier deletion", func() {
+	It("should keep a deleted Tier until its limits can be removed", func() {
+		now := metav1.Now()
+		platform := &myappv1alpha1.MaasPlatform{
+			ObjectMeta: metav1.ObjectMeta{Name: "maas-platform", Namespace: "maas-system"},
+			Spec: myappv1alpha1.MaasPlatformSpec{
+				TierCleanup: &myappv1alpha1.TierCleanupConfig{Policy: myappv1alpha1.TierCleanupReset},
+			},
+			Status: myappv1alpha1.MaasPlatformStatus{Phase: myappv1alpha1.DeploymentPhaseGateway},
+		}
+		tier := &myappv1alpha1.Tier{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "premium", Namespace: "maas-system", UID: "premium",
+				Finalizers: []string{tierFinalizer}, DeletionTimestamp: &now,
+			},
+			Spec: myappv1alpha1.TierSpec{TargetRef: myappv1alpha1.MaasPlatformTargetRef{Name: "maas-platform"}},
+		}
+		reconciler := &TierReconciler{Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(platform, tier).Build()}
+		request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(tier)}
+
+		result, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		Expect(reconciler.Get(ctx, request.NamespacedName, &myappv1alpha1.Tier{})).To(Succeed())
+
+		By("letting it go once the limits are removed")
+		platform.Spec.TierCleanup = nil
+		Expect(reconciler.Update(ctx, platform)).To(Succeed())
+		result, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.IsZero()).To(BeTrue())
+		err = reconciler.Get(ctx, request.NamespacedName, &myappv1alpha1.Tier{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
 	})
 })
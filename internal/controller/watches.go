@@ -0,0 +1,181 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/jland-redhat/maas-operator.git/pkg/render"
+)
+
+var (
+	gatewayGVK    = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "Gateway"}
+	httpRouteGVK  = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+	authPolicyGVK = schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1", Kind: "AuthPolicy"}
+)
+
+// platformForObject returns the MaasPlatform an object is labelled with.
+// Namespaces cannot contain dots, so the label value splits on its last dot.
+func platformForObject(obj client.Object) (types.NamespacedName, bool) {
+	value, ok := obj.GetLabels()[render.PlatformLabel]
+	if !ok {
+		return types.NamespacedName{}, false
+	}
+	i := strings.LastIndex(value, ".")
+	if i <= 0 || i == len(value)-1 {
+		return types.NamespacedName{}, false
+	}
+	return types.NamespacedName{Name: value[:i], Namespace: value[i+1:]}, true
+}
+
+// managedByPlatform only lets through events for objects labelled with a MaasPlatform
+var managedByPlatform = predicate.NewPredicateFuncs(func(obj client.Object) bool {
+	_, ok := platformForObject(obj)
+	return ok
+})
+
+// managedWatches adds the watches of a controller. Kinds whose CRD is not installed are held
+// back so a missing add-on does not stop the manager, and are watched once their CRD is installed.
+type managedWatches struct {
+	mapper meta.RESTMapper
+	cache  cache.Cache
+	log    logr.Logger
+
+	mu         sync.Mutex
+	controller controller.Controller
+	pending    []pendingWatch
+}
+
+// pendingWatch is a watch waiting for the CRD of its kind
+type pendingWatch struct {
+	obj        client.Object
+	handler    handler.EventHandler
+	predicates []predicate.Predicate
+}
+
+// newManagedWatches returns the watches of a controller of the manager
+func newManagedWatches(mgr ctrl.Manager) *managedWatches {
+	return &managedWatches{mapper: mgr.GetRESTMapper(), cache: mgr.GetCache(), log: mgr.GetLogger()}
+}
+
+// watchManaged adds a label-mapped watch for a managed kind to the controller builder
+func (w *managedWatches) watchManaged(b *builder.Builder, obj client.Object, mapFunc handler.MapFunc, predicates ...predicate.Predicate) *builder.Builder {
+	predicates = append([]predicate.Predicate{managedByPlatform}, predicates...)
+	return w.watch(b, obj, handler.EnqueueRequestsFromMapFunc(mapFunc), predicates...)
+}
+
+// watch adds a watch to the controller builder, or holds it back until its kind is installed
+func (w *managedWatches) watch(b *builder.Builder, obj client.Object, h handler.EventHandler, predicates ...predicate.Predicate) *builder.Builder {
+	if !w.kindInstalled(obj) {
+		gvk := obj.GetObjectKind().GroupVersionKind()
+		w.log.Info("Kind not installed, watching it once its CRD is installed", "kind", gvk.Kind, "group", gvk.Group)
+		w.pending = append(w.pending, pendingWatch{obj: obj, handler: h, predicates: predicates})
+		return b
+	}
+	return b.Watches(obj, h, builder.WithPredicates(predicates...))
+}
+
+// complete builds the controller. While watches are held back, the prerequisite CRDs are
+// watched as well to add them once their kind is installed.
+func (w *managedWatches) complete(b *builder.Builder, r reconcile.Reconciler) error {
+	if len(w.pending) > 0 {
+		crd := &metav1.PartialObjectMetadata{}
+		crd.SetGroupVersionKind(crdGVK)
+		b = b.WatchesMetadata(crd, handler.Funcs{
+			CreateFunc: func(context.Context, event.CreateEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+				w.addInstalled()
+			},
+			// CRDs are served once they are established, which is a status update
+			UpdateFunc: func(context.Context, event.UpdateEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+				w.addInstalled()
+			},
+		}, builder.WithPredicates(prerequisiteCRD))
+	}
+
+	c, err := b.Build(r)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.controller = c
+	return nil
+}
+
+// addInstalled adds the held back watches whose kind is now installed to the running controller
+func (w *managedWatches) addInstalled() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.controller == nil {
+		return
+	}
+
+	pending := w.pending[:0]
+	for _, p := range w.pending {
+		if !w.kindInstalled(p.obj) {
+			pending = append(pending, p)
+			continue
+		}
+		gvk := p.obj.GetObjectKind().GroupVersionKind()
+		if err := w.controller.Watch(source.Kind(w.cache, p.obj, p.handler, p.predicates...)); err != nil {
+			w.log.Error(err, "Failed to watch installed kind", "kind", gvk.Kind, "group", gvk.Group)
+			pending = append(pending, p)
+			continue
+		}
+		w.log.Info("Kind installed, watching it", "kind", gvk.Kind, "group", gvk.Group)
+	}
+	w.pending = pending
+}
+
+// kindInstalled reports whether the kind of an unstructured watch source is served by the cluster.
+// Typed objects are always assumed to be installed.
+func (w *managedWatches) kindInstalled(obj client.Object) bool {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return true
+	}
+	gvk := u.GroupVersionKind()
+	_, err := w.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	return !meta.IsNoMatchError(err)
+}
+
+// newUnstructured returns an empty unstructured object of the given kind, used as a watch source
+func newUnstructured(gvk schema.GroupVersionKind) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	return obj
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+	"github.com/jland-redhat/maas-operator.git/pkg/render"
+)
+
+// nolint:unused
+// log is for logging in this package.
+var maasplatformlog = logf.Log.WithName("maasplatform-resource")
+
+// SetupMaasPlatformWebhookWithManager registers the webhook for MaasPlatform in the manager.
+func SetupMaasPlatformWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&myappv1alpha1.MaasPlatform{}).
+		WithValidator(&MaasPlatformCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-myapp-io-odh-maas-v1alpha1-maasplatform,mutating=false,failurePolicy=fail,sideEffects=None,groups=myapp.io.odh.maas,resources=maasplatforms,verbs=create;update,versions=v1alpha1,name=vmaasplatform-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// MaasPlatformCustomValidator struct is responsible for validating the MaasPlatform resource
+// when it is created or updated.
+type MaasPlatformCustomValidator struct {
+	Client client.Client
+}
+
+var _ webhook.CustomValidator = &MaasPlatformCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type MaasPlatform.
+func (v *MaasPlatformCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	maasplatform, ok := obj.(*myappv1alpha1.MaasPlatform)
+	if !ok {
+		return nil, fmt.Errorf("expected a MaasPlatform object but got %T", obj)
+	}
+	maasplatformlog.Info("Validation for MaasPlatform upon creation", "name", maasplatform.GetName())
+
+	if err := validatePlatformLabel(maasplatform); err != nil {
+		return nil, err
+	}
+	if err := maasplatform.Spec.Validate(); err != nil {
+		return nil, err
+	}
+	if err := validatePatchTargets(maasplatform); err != nil {
+		return nil, err
+	}
+	return nil, v.validateUnique(ctx, maasplatform)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type MaasPlatform.
+func (v *MaasPlatformCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	maasplatform, ok := newObj.(*myappv1alpha1.MaasPlatform)
+	if !ok {
+		return nil, fmt.Errorf("expected a MaasPlatform object for the newObj but got %T", newObj)
+	}
+	maasplatformlog.Info("Validation for MaasPlatform upon update", "name", maasplatform.GetName())
+
+	oldMaasplatform, ok := oldObj.(*myappv1alpha1.MaasPlatform)
+	if !ok {
+		return nil, fmt.Errorf("expected a MaasPlatform object for the oldObj but got %T", oldObj)
+	}
+
+	// Allow finalizer and metadata updates on MaasPlatforms that are being deleted
+	if !maasplatform.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+
+	// Moving the components would leave the old namespaces behind
+	if oldMaasplatform.Spec.APINamespace() != maasplatform.Spec.APINamespace() ||
+		oldMaasplatform.Spec.GatewayNamespace() != maasplatform.Spec.GatewayNamespace() ||
+		oldMaasplatform.Spec.KuadrantNamespace() != maasplatform.Spec.KuadrantNamespace() {
+		return nil, fmt.Errorf("spec.namespaces cannot be changed after creation")
+	}
+
+	// Renaming the objects would leave the old ones behind. The operator picks the naming
+	// before it deploys anything, which is when the platform gets its finalizer.
+	oldNaming, hadNaming := oldMaasplatform.Annotations[myappv1alpha1.NamingAnnotation]
+	if naming := maasplatform.Annotations[myappv1alpha1.NamingAnnotation]; naming != oldNaming &&
+		(hadNaming || len(oldMaasplatform.Finalizers) > 0) {
+		return nil, fmt.Errorf("the %s annotation cannot be changed once the platform is deployed", myappv1alpha1.NamingAnnotation)
+	}
+
+	if err := maasplatform.Spec.Validate(); err != nil {
+		return nil, err
+	}
+	if err := validatePatchTargets(maasplatform); err != nil {
+		return nil, err
+	}
+	return nil, v.validateUnique(ctx, maasplatform)
+}
+
+// validateUnique makes sure a MaasPlatform does not clash with the other platforms.
+// Generated object names derive from the platform name and the namespaces are shared
+// by default, so names must be unique across namespaces; gateways need their own hostname.
+func (v *MaasPlatformCustomValidator) validateUnique(ctx context.Context, maasplatform *myappv1alpha1.MaasPlatform) error {
+	platformList := &myappv1alpha1.MaasPlatformList{}
+	if err := v.Client.List(ctx, platformList); err != nil {
+		return fmt.Errorf("failed to list MaasPlatforms: %w", err)
+	}
+	for _, other := range platformList.Items {
+		if other.Namespace == maasplatform.Namespace && other.Name == maasplatform.Name {
+			continue
+		}
+		if other.Name == maasplatform.Name {
+			return fmt.Errorf("MaasPlatform names must be unique across namespaces, %s/%s already exists",
+				other.Namespace, other.Name)
+		}
+		if other.UsesLegacyNames() && maasplatform.UsesLegacyNames() {
+			return fmt.Errorf("MaasPlatform %s/%s already uses the legacy object names", other.Namespace, other.Name)
+		}
+		if gatewayHostname(&other) == gatewayHostname(maasplatform) {
+			hostname := gatewayHostname(maasplatform)
+			if hostname == "" {
+				hostname = "the default hostname"
+			}
+			return fmt.Errorf("MaasPlatform %s/%s already serves %s, set a different spec.gateway.hostname",
+				other.Namespace, other.Name, hostname)
+		}
+	}
+	return nil
+}
+
+// validatePlatformLabel denies platforms whose name and namespace do not fit in the value of
+// the platform label, which every managed object carries. Both are immutable, so this is only
+// checked on creation.
+func validatePlatformLabel(maasplatform *myappv1alpha1.MaasPlatform) error {
+	value := render.PlatformLabelValue(maasplatform)
+	if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+		return fmt.Errorf("the name and namespace of a MaasPlatform must fit in the %s label value %q "+
+			"(at most %d characters together with the separating dot)", render.PlatformLabel, value, validation.LabelValueMaxLength)
+	}
+	return nil
+}
+
+// validatePatchTargets denies patches on the tier ConfigMap and policies. The Tier controller
+// writes them from the Tiers, so the patches of the platform never reach them.
+func validatePatchTargets(maasplatform *myappv1alpha1.MaasPlatform) error {
+	tierObjects, err := render.RenderTiers(nil, maasplatform, render.ClusterFacts{})
+	if err != nil {
+		return err
+	}
+	for i, p := range maasplatform.Spec.Patches {
+		for _, obj := range tierObjects {
+			if p.Target.Matches(obj.GetKind(), obj.GetNamespace(), obj.GetName()) {
+				return fmt.Errorf("spec.patches[%d] targets %s %s/%s, which is written from the Tiers and cannot be patched",
+					i, obj.GetKind(), obj.GetNamespace(), obj.GetName())
+			}
+		}
+	}
+	return nil
+}
+
+// gatewayHostname returns the configured gateway hostname, empty for the default one
+func gatewayHostname(maasplatform *myappv1alpha1.MaasPlatform) string {
+	if maasplatform.Spec.Gateway == nil {
+		return ""
+	}
+	return maasplatform.Spec.Gateway.Hostname
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type MaasPlatform.
+func (v *MaasPlatformCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+	"github.com/jland-redhat/maas-operator.git/pkg/render"
+)
+
+var _ = Describe("MaasPlatform Webhook", func() {
+	var (
+		ctx context.Context
+		obj *myappv1alpha1.MaasPlatform
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		obj = &myappv1alpha1.MaasPlatform{
+			ObjectMeta: metav1.ObjectMeta{Name: "maas-platform", Namespace: "maas-system"},
+		}
+	})
+
+	Context("When creating MaasPlatform under Validating Webhook", func() {
+		It("Should admit the first MaasPlatform", func() {
+			validator := MaasPlatformCustomValidator{Client: newFakeClient()}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny a name and namespace that do not fit in the platform label", func() {
+			obj.Name = strings.Repeat("a", 40)
+			obj.Namespace = strings.Repeat("b", 23)
+			validator := MaasPlatformCustomValidator{Client: newFakeClient()}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("must fit in the maas-platform label value")))
+
+			obj.Namespace = strings.Repeat("b", 22)
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should require the domain and GatewayClass with the kubernetes profile", func() {
+			obj.Spec.Profile = myappv1alpha1.ProfileKubernetes
+			validator := MaasPlatformCustomValidator{Client: newFakeClient()}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("clusterDomain is required")))
+
+			obj.Spec.ClusterDomain = "maas.localtest.me"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("gateway.gatewayClassName is required")))
+
+			obj.Spec.Gateway = &myappv1alpha1.GatewayConfig{GatewayClassName: "istio"}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should require the connection Secret with the PostgreSQL database", func() {
+			obj.Spec.MaasAPI = &myappv1alpha1.MaasAPIConfig{
+				Database: &myappv1alpha1.MaasAPIDatabaseConfig{Type: myappv1alpha1.DatabaseTypePostgreSQL},
+			}
+			validator := MaasPlatformCustomValidator{Client: newFakeClient()}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("urlSecretRef name and key are required")))
+
+			obj.Spec.MaasAPI.Database.PostgreSQL = &myappv1alpha1.PostgreSQLDatabaseConfig{
+				URLSecretRef: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "maas-db"}, Key: "url"},
+			}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should only allow autoscaling without replicas and with the PostgreSQL database", func() {
+			replicas := int32(2)
+			obj.Spec.MaasAPI = &myappv1alpha1.MaasAPIConfig{
+				Replicas:    &replicas,
+				Autoscaling: &myappv1alpha1.MaasAPIAutoscalingConfig{MaxReplicas: 4},
+			}
+			validator := MaasPlatformCustomValidator{Client: newFakeClient()}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("replicas cannot be set with maasAPI.autoscaling")))
+
+			obj.Spec.MaasAPI.Replicas = nil
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("requires the PostgreSQL database")))
+
+			obj.Spec.MaasAPI.Database = &myappv1alpha1.MaasAPIDatabaseConfig{
+				Type: myappv1alpha1.DatabaseTypePostgreSQL,
+				PostgreSQL: &myappv1alpha1.PostgreSQLDatabaseConfig{
+					URLSecretRef: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "maas-db"}, Key: "url"},
+				},
+			}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should admit a second MaasPlatform with its own name and hostname", func() {
+			existing := &myappv1alpha1.MaasPlatform{
+				ObjectMeta: metav1.ObjectMeta{Name: "staging", Namespace: "maas-staging"},
+				Spec: myappv1alpha1.MaasPlatformSpec{
+					Gateway: &myappv1alpha1.GatewayConfig{Hostname: "maas-staging.apps.example.com"},
+				},
+			}
+			validator := MaasPlatformCustomValidator{Client: newFakeClient(existing)}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny a MaasPlatform with the name of another one", func() {
+			existing := &myappv1alpha1.MaasPlatform{
+				ObjectMeta: metav1.ObjectMeta{Name: "maas-platform", Namespace: "maas-staging"},
+				Spec: myappv1alpha1.MaasPlatformSpec{
+					Gateway: &myappv1alpha1.GatewayConfig{Hostname: "maas-staging.apps.example.com"},
+				},
+			}
+			validator := MaasPlatformCustomValidator{Client: newFakeClient(existing)}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("unique across namespaces")))
+		})
+
+		It("Should deny a second MaasPlatform on the same gateway hostname", func() {
+			existing := &myappv1alpha1.MaasPlatform{
+				ObjectMeta: metav1.ObjectMeta{Name: "staging", Namespace: "maas-staging"},
+			}
+			validator := MaasPlatformCustomValidator{Client: newFakeClient(existing)}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.gateway.hostname")))
+		})
+
+		It("Should deny a second MaasPlatform with the legacy names", func() {
+			legacy := map[string]string{myappv1alpha1.NamingAnnotation: myappv1alpha1.NamingLegacy}
+			existing := &myappv1alpha1.MaasPlatform{
+				ObjectMeta: metav1.ObjectMeta{Name: "staging", Namespace: "maas-staging", Annotations: legacy},
+				Spec: myappv1alpha1.MaasPlatformSpec{
+					Gateway: &myappv1alpha1.GatewayConfig{Hostname: "maas-staging.apps.example.com"},
+				},
+			}
+			obj.Annotations = legacy
+			validator := MaasPlatformCustomValidator{Client: newFakeClient(existing)}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("legacy object names")))
+		})
+
+		It("Should deny patches on the objects written from the Tiers", func() {
+			validator := MaasPlatformCustomValidator{Client: newFakeClient()}
+			names := render.NamesFor(obj)
+			for _, target := range []myappv1alpha1.PatchTarget{
+				{Kind: "ConfigMap", Name: names.TierConfigMap},
+				{Kind: "RateLimitPolicy", Name: names.RateLimitPolicy},
+				{Kind: "TokenRateLimitPolicy", Name: names.TokenRateLimitPolicy, Namespace: obj.Spec.GatewayNamespace()},
+			} {
+				obj.Spec.Patches = []myappv1alpha1.ObjectPatch{{Target: target, Patch: "metadata: {}"}}
+				Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("cannot be patched")))
+			}
+
+			obj.Spec.Patches = []myappv1alpha1.ObjectPatch{{
+				Target: myappv1alpha1.PatchTarget{Kind: "ConfigMap", Name: names.TierConfigMap, Namespace: "other"},
+				Patch:  "metadata: {}",
+			}}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When updating MaasPlatform under Validating Webhook", func() {
+		It("Should allow spelling out the default namespaces", func() {
+			updated := obj.DeepCopy()
+			updated.Spec.Namespaces = &myappv1alpha1.NamespacesConfig{API: myappv1alpha1.DefaultAPINamespace}
+			validator := MaasPlatformCustomValidator{Client: newFakeClient()}
+			Expect(validator.ValidateUpdate(ctx, obj, updated)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny changing a namespace", func() {
+			updated := obj.DeepCopy()
+			updated.Spec.Namespaces = &myappv1alpha1.NamespacesConfig{Gateway: "maas-gateway"}
+			validator := MaasPlatformCustomValidator{Client: newFakeClient()}
+			Expect(validator.ValidateUpdate(ctx, obj, updated)).Error().To(MatchError(ContainSubstring("spec.namespaces cannot be changed")))
+		})
+
+		It("Should only let the naming be picked before the platform is deployed", func() {
+			updated := obj.DeepCopy()
+			updated.Finalizers = []string{"myapp.io.odh.maas/finalizer"}
+			updated.Annotations = map[string]string{myappv1alpha1.NamingAnnotation: myappv1alpha1.NamingLegacy}
+			validator := MaasPlatformCustomValidator{Client: newFakeClient()}
+			Expect(validator.ValidateUpdate(ctx, obj, updated)).Error().NotTo(HaveOccurred())
+
+			renamed := updated.DeepCopy()
+			delete(renamed.Annotations, myappv1alpha1.NamingAnnotation)
+			Expect(validator.ValidateUpdate(ctx, updated, renamed)).Error().To(MatchError(ContainSubstring("cannot be changed")))
+
+			deployed := obj.DeepCopy()
+			deployed.Finalizers = updated.Finalizers
+			Expect(validator.ValidateUpdate(ctx, deployed, updated)).Error().To(MatchError(ContainSubstring("cannot be changed")))
+		})
+
+		It("Should allow removing the finalizer of a MaasPlatform being deleted", func() {
+			obj.Finalizers = []string{"myapp.io.odh.maas/finalizer"}
+			existing := &myappv1alpha1.MaasPlatform{
+				ObjectMeta: metav1.ObjectMeta{Name: "staging", Namespace: "maas-staging"},
+			}
+			validator := MaasPlatformCustomValidator{Client: newFakeClient(existing)}
+			Expect(validator.ValidateUpdate(ctx, obj, obj)).Error().To(HaveOccurred(), "another platform serves the same hostname")
+
+			now := metav1.Now()
+			obj.DeletionTimestamp = &now
+			updated := obj.DeepCopy()
+			updated.Finalizers = nil
+			Expect(validator.ValidateUpdate(ctx, obj, updated)).Error().NotTo(HaveOccurred())
+		})
+	})
+})
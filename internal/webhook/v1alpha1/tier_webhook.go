@@ -0,0 +1,175 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+)
+
+// nolint:unused
+// log is for logging in this package.
+var tierlog = logf.Log.WithName("tier-resource")
+
+// SetupTierWebhookWithManager registers the webhook for Tier in the manager.
+func SetupTierWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&myappv1alpha1.Tier{}).
+		WithValidator(&TierCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-myapp-io-odh-maas-v1alpha1-tier,mutating=false,failurePolicy=fail,sideEffects=None,groups=myapp.io.odh.maas,resources=tiers,verbs=create;update,versions=v1alpha1,name=vtier-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// TierCustomValidator struct is responsible for validating the Tier resource
+// when it is created or updated.
+type TierCustomValidator struct {
+	Client client.Client
+}
+
+var _ webhook.CustomValidator = &TierCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Tier.
+func (v *TierCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	tier, ok := obj.(*myappv1alpha1.Tier)
+	if !ok {
+		return nil, fmt.Errorf("expected a Tier object but got %T", obj)
+	}
+	tierlog.Info("Validation for Tier upon creation", "name", tier.GetName())
+
+	return nil, v.validateTier(ctx, tier)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Tier.
+func (v *TierCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	tier, ok := newObj.(*myappv1alpha1.Tier)
+	if !ok {
+		return nil, fmt.Errorf("expected a Tier object for the newObj but got %T", newObj)
+	}
+	tierlog.Info("Validation for Tier upon update", "name", tier.GetName())
+
+	// Allow finalizer and metadata updates on Tiers that are being deleted
+	if !tier.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+
+	return nil, v.validateTier(ctx, tier)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Tier.
+func (v *TierCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateTier validates the Tier spec and its relationship with the other Tiers of the target platform
+func (v *TierCustomValidator) validateTier(ctx context.Context, tier *myappv1alpha1.Tier) error {
+	if err := tier.Spec.Validate(); err != nil {
+		return err
+	}
+
+	if tier.Spec.RateLimits != nil {
+		if err := validateCounters("rateLimits.counters", tier.Spec.RateLimits.Counters); err != nil {
+			return err
+		}
+	}
+	if tier.Spec.TokenRateLimits != nil {
+		if err := validateCounters("tokenRateLimits.counters", tier.Spec.TokenRateLimits.Counters); err != nil {
+			return err
+		}
+	}
+	for i, modelLimit := range tier.Spec.ModelLimits {
+		if modelLimit.RateLimits != nil {
+			if err := validateCounters(fmt.Sprintf("modelLimits[%d].rateLimits.counters", i), modelLimit.RateLimits.Counters); err != nil {
+				return err
+			}
+		}
+		if modelLimit.TokenRateLimits != nil {
+			if err := validateCounters(fmt.Sprintf("modelLimits[%d].tokenRateLimits.counters", i), modelLimit.TokenRateLimits.Counters); err != nil {
+				return err
+			}
+		}
+	}
+
+	// The target MaasPlatform must exist
+	platformKey := client.ObjectKey{Name: tier.Spec.TargetRef.Name, Namespace: tier.TargetNamespace()}
+	if err := v.Client.Get(ctx, platformKey, &myappv1alpha1.MaasPlatform{}); err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("targetRef: MaasPlatform %s not found", platformKey)
+		}
+		return fmt.Errorf("failed to get MaasPlatform %s: %w", platformKey, err)
+	}
+
+	// Tier names must be unique per MaasPlatform since they key the policy limits, and so must
+	// the levels that are set. Tiers left at the default level 0 are ordered by name.
+	tierList := &myappv1alpha1.TierList{}
+	if err := v.Client.List(ctx, tierList); err != nil {
+		return fmt.Errorf("failed to list Tiers: %w", err)
+	}
+	for _, other := range tierList.Items {
+		if other.Namespace == tier.Namespace && other.Name == tier.Name {
+			continue
+		}
+		if other.Spec.TargetRef.Name != tier.Spec.TargetRef.Name || other.TargetNamespace() != tier.TargetNamespace() {
+			continue
+		}
+		if other.Name == tier.Name {
+			return fmt.Errorf("tier name %q is already used by Tier %s/%s targeting MaasPlatform %s",
+				tier.Name, other.Namespace, other.Name, platformKey)
+		}
+		if tier.Spec.Level != 0 && other.Spec.Level == tier.Spec.Level {
+			return fmt.Errorf("level %d is already used by Tier %s/%s targeting MaasPlatform %s",
+				tier.Spec.Level, other.Namespace, other.Name, platformKey)
+		}
+	}
+
+	return nil
+}
+
+// validateCounters checks that every counter is a valid CEL expression over the
+// well-known attributes Kuadrant exposes to rate limit policies
+func validateCounters(field string, counters []string) error {
+	env, err := cel.NewEnv(
+		cel.Variable("auth", cel.DynType),
+		cel.Variable("request", cel.DynType),
+		cel.Variable("source", cel.DynType),
+		cel.Variable("destination", cel.DynType),
+		cel.Variable("connection", cel.DynType),
+		cel.Variable("metadata", cel.DynType),
+		cel.Variable("filter_state", cel.DynType),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+
+	for i, counter := range counters {
+		if _, issues := env.Compile(counter); issues != nil && issues.Err() != nil {
+			return fmt.Errorf("%s[%d]: invalid CEL expression %q: %w", field, i, counter, issues.Err())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+)
+
+var _ = Describe("Tier Webhook", func() {
+	var (
+		ctx      context.Context
+		platform *myappv1alpha1.MaasPlatform
+		obj      *myappv1alpha1.Tier
+	)
+
+	newTier := func(name string, level int32) *myappv1alpha1.Tier {
+		return &myappv1alpha1.Tier{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "maas-system"},
+			Spec: myappv1alpha1.TierSpec{
+				TargetRef: myappv1alpha1.MaasPlatformTargetRef{Name: "maas-platform"},
+				Level:     level,
+				RateLimits: &myappv1alpha1.TierRateLimitConfig{
+					Limit:    10,
+					Window:   "2m",
+					Counters: []string{"auth.identity.userid"},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		platform = &myappv1alpha1.MaasPlatform{
+			ObjectMeta: metav1.ObjectMeta{Name: "maas-platform", Namespace: "maas-system"},
+		}
+		obj = newTier("free", 0)
+	})
+
+	Context("When creating or updating Tier under Validating Webhook", func() {
+		It("Should admit a valid Tier", func() {
+			validator := TierCustomValidator{Client: newFakeClient(platform)}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny an unparsable window", func() {
+			obj.Spec.RateLimits.Window = "two minutes"
+			validator := TierCustomValidator{Client: newFakeClient(platform)}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("window")))
+		})
+
+		It("Should deny a non-positive limit", func() {
+			obj.Spec.RateLimits.Limit = 0
+			validator := TierCustomValidator{Client: newFakeClient(platform)}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("limit")))
+		})
+
+		It("Should deny invalid CEL in counters", func() {
+			obj.Spec.RateLimits.Counters = []string{"auth.identity.userid +"}
+			validator := TierCustomValidator{Client: newFakeClient(platform)}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("invalid CEL expression")))
+		})
+
+		It("Should deny a targetRef to a missing MaasPlatform", func() {
+			validator := TierCustomValidator{Client: newFakeClient()}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("not found")))
+		})
+
+		It("Should deny a duplicate level on the same MaasPlatform", func() {
+			obj.Spec.Level = 1
+			validator := TierCustomValidator{Client: newFakeClient(platform, newTier("premium", 1))}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("level 1")))
+		})
+
+		It("Should admit several Tiers left at the default level", func() {
+			validator := TierCustomValidator{Client: newFakeClient(platform, newTier("premium", 0))}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny a duplicate name on the same MaasPlatform", func() {
+			other := newTier("free", 1)
+			other.Namespace = "other-team"
+			other.Spec.TargetRef.Namespace = "maas-system"
+			validator := TierCustomValidator{Client: newFakeClient(platform, other)}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("tier name")))
+		})
+
+		It("Should admit an update of the Tier itself", func() {
+			validator := TierCustomValidator{Client: newFakeClient(platform, obj.DeepCopy())}
+			updated := obj.DeepCopy()
+			updated.Spec.RateLimits.Limit = 20
+			Expect(validator.ValidateUpdate(ctx, obj, updated)).Error().NotTo(HaveOccurred())
+		})
+	})
+})
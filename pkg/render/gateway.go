@@ -0,0 +1,196 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+)
+
+const defaultGatewayTLSSecret = "default-gateway-tls"
+
+// customizeGateway renders the MaasPlatform gateway settings into the platform gateway object
+func customizeGateway(obj *unstructured.Unstructured, maasPlatform *myappv1alpha1.MaasPlatform) error {
+	gatewayConfig := maasPlatform.Spec.Gateway
+	if gatewayConfig == nil {
+		return nil
+	}
+
+	listeners, _, err := unstructured.NestedSlice(obj.Object, "spec", "listeners")
+	if err != nil {
+		return fmt.Errorf("failed to read gateway listeners: %w", err)
+	}
+
+	tlsSecret := defaultGatewayTLSSecret
+	if gatewayConfig.TLS != nil && gatewayConfig.TLS.SecretName != "" {
+		tlsSecret = gatewayConfig.TLS.SecretName
+	}
+
+	// Override the default listeners and remember the effective hostname for extra listeners
+	hostname := gatewayConfig.Hostname
+	for i, l := range listeners {
+		listener, ok := l.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if hostname != "" {
+			listener["hostname"] = hostname
+		} else if h, ok := listener["hostname"].(string); ok {
+			hostname = h
+		}
+		if listener["protocol"] == "HTTPS" {
+			listener["tls"] = gatewayListenerTLS(tlsSecret)
+		}
+		listeners[i] = listener
+	}
+
+	for _, extra := range gatewayConfig.Listeners {
+		listener := map[string]interface{}{
+			"name":     extra.Name,
+			"port":     int64(extra.Port),
+			"protocol": extra.Protocol,
+			"allowedRoutes": map[string]interface{}{
+				"namespaces": map[string]interface{}{
+					"from": "All",
+				},
+			},
+		}
+
+		listenerHostname := extra.Hostname
+		if listenerHostname == "" {
+			listenerHostname = hostname
+		}
+		if listenerHostname != "" {
+			listener["hostname"] = listenerHostname
+		}
+
+		if extra.Protocol == "HTTPS" {
+			secret := extra.TLSSecretName
+			if secret == "" {
+				secret = tlsSecret
+			}
+			listener["tls"] = gatewayListenerTLS(secret)
+		}
+		listeners = append(listeners, listener)
+	}
+
+	if err := unstructured.SetNestedSlice(obj.Object, listeners, "spec", "listeners"); err != nil {
+		return fmt.Errorf("failed to set gateway listeners: %w", err)
+	}
+
+	// cert-manager's gateway-shim issues certificates for HTTPS listeners of annotated gateways
+	if gatewayConfig.TLS != nil && gatewayConfig.TLS.CertManagerIssuer != nil {
+		issuer := gatewayConfig.TLS.CertManagerIssuer
+		annotation := "cert-manager.io/cluster-issuer"
+		if issuer.Kind == "Issuer" {
+			annotation = "cert-manager.io/issuer"
+		}
+
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[annotation] = issuer.Name
+		obj.SetAnnotations(annotations)
+	}
+
+	return nil
+}
+
+// gatewayListenerTLS builds the tls block of an HTTPS listener terminating with the given secret
+func gatewayListenerTLS(secretName string) map[string]interface{} {
+	return map[string]interface{}{
+		"mode": "Terminate",
+		"certificateRefs": []interface{}{
+			map[string]interface{}{
+				"group": "",
+				"kind":  "Secret",
+				"name":  secretName,
+			},
+		},
+	}
+}
+
+// customizeMaasAPIRoute attaches the maas-api HTTPRoute to the https listener only when
+// HTTP requests are redirected, so the redirect route owns the http listener
+func customizeMaasAPIRoute(obj *unstructured.Unstructured, maasPlatform *myappv1alpha1.MaasPlatform) error {
+	if maasPlatform.Spec.Gateway == nil || !maasPlatform.Spec.Gateway.RedirectHTTPToHTTPS {
+		return nil
+	}
+
+	parentRefs, _, err := unstructured.NestedSlice(obj.Object, "spec", "parentRefs")
+	if err != nil {
+		return fmt.Errorf("failed to read HTTPRoute parentRefs: %w", err)
+	}
+	gatewayName := NamesFor(maasPlatform).Gateway
+	for i, p := range parentRefs {
+		parentRef, ok := p.(map[string]interface{})
+		if !ok || parentRef["name"] != gatewayName {
+			continue
+		}
+		parentRef["sectionName"] = "https"
+		parentRefs[i] = parentRef
+	}
+
+	return unstructured.SetNestedSlice(obj.Object, parentRefs, "spec", "parentRefs")
+}
+
+// HTTPSRedirectRoute builds the HTTPRoute that redirects the http listener of the platform gateway to HTTPS
+func HTTPSRedirectRoute(maasPlatform *myappv1alpha1.MaasPlatform) *unstructured.Unstructured {
+	names := NamesFor(maasPlatform)
+	namespace := maasPlatform.Spec.GatewayNamespace()
+	route := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "gateway.networking.k8s.io/v1",
+			"kind":       "HTTPRoute",
+			"metadata": map[string]interface{}{
+				"name":      names.GatewayRedirectRoute,
+				"namespace": namespace,
+				"labels": map[string]interface{}{
+					"app.kubernetes.io/component": "gateway",
+					"app.kubernetes.io/name":      "maas",
+				},
+			},
+			"spec": map[string]interface{}{
+				"parentRefs": []interface{}{
+					map[string]interface{}{
+						"name":        names.Gateway,
+						"namespace":   namespace,
+						"sectionName": "http",
+					},
+				},
+				"rules": []interface{}{
+					map[string]interface{}{
+						"filters": []interface{}{
+							map[string]interface{}{
+								"type": "RequestRedirect",
+								"requestRedirect": map[string]interface{}{
+									"scheme":     "https",
+									"statusCode": int64(301),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	return route
+}
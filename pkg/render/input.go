@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+)
+
+// ReadInput adds the MaasPlatform, Tiers and LLMInferenceServices of a multi-document YAML
+// or JSON stream to the input. Objects without a namespace get the given one, unknown fields
+// and other kinds are rejected, and only one MaasPlatform can be read.
+func ReadInput(in *Input, reader io.Reader, namespace string) error {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(reader, 4096)
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(namespace)
+		}
+		if err := in.add(obj); err != nil {
+			return err
+		}
+	}
+}
+
+// add adds a decoded object to the input
+func (in *Input) add(obj *unstructured.Unstructured) error {
+	switch obj.GroupVersionKind() {
+	case myappv1alpha1.GroupVersion.WithKind("MaasPlatform"):
+		if in.Platform != nil {
+			return fmt.Errorf("found a second MaasPlatform %s, only one can be rendered at a time", obj.GetName())
+		}
+		platform := &myappv1alpha1.MaasPlatform{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructuredWithValidation(obj.Object, platform, true); err != nil {
+			return fmt.Errorf("invalid MaasPlatform %s: %w", obj.GetName(), err)
+		}
+		in.Platform = platform
+	case myappv1alpha1.GroupVersion.WithKind("Tier"):
+		tier := myappv1alpha1.Tier{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructuredWithValidation(obj.Object, &tier, true); err != nil {
+			return fmt.Errorf("invalid Tier %s: %w", obj.GetName(), err)
+		}
+		in.Tiers = append(in.Tiers, tier)
+	case LLMInferenceServiceGVK:
+		in.Cluster.Models = append(in.Cluster.Models, ModelsFrom([]unstructured.Unstructured{*obj})...)
+	default:
+		return fmt.Errorf("unsupported kind %s %s", obj.GetAPIVersion(), obj.GetKind())
+	}
+	return nil
+}
+
+// WriteYAML writes rendered objects as a multi-document YAML stream, the way kubectl reads them
+func WriteYAML(w io.Writer, objects []*unstructured.Unstructured) error {
+	for i, obj := range objects {
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s %s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		if i > 0 {
+			if _, err := io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"fmt"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+)
+
+// maasAPIContainerName is the container customized in the maas-api Deployment
+const maasAPIContainerName = "maas-api"
+
+// defaultDatabaseSize is the size of the embedded database PVC
+const defaultDatabaseSize = "1Gi"
+
+// customizeMaasAPIDeployment renders the MaasPlatform maasAPI settings into the maas-api Deployment
+func customizeMaasAPIDeployment(obj *unstructured.Unstructured, maasPlatform *myappv1alpha1.MaasPlatform) error {
+	config := maasPlatform.Spec.MaasAPI
+	if config == nil {
+		return nil
+	}
+
+	if config.Replicas != nil {
+		if err := unstructured.SetNestedField(obj.Object, int64(*config.Replicas), "spec", "replicas"); err != nil {
+			return fmt.Errorf("failed to set replicas: %w", err)
+		}
+	}
+	if err := customizeMaasAPIAvailability(obj, maasPlatform); err != nil {
+		return err
+	}
+
+	podSpec := []string{"spec", "template", "spec"}
+	if len(config.NodeSelector) > 0 {
+		if err := unstructured.SetNestedStringMap(obj.Object, config.NodeSelector, append(podSpec, "nodeSelector")...); err != nil {
+			return fmt.Errorf("failed to set nodeSelector: %w", err)
+		}
+	}
+	if config.PriorityClassName != "" {
+		if err := unstructured.SetNestedField(obj.Object, config.PriorityClassName, append(podSpec, "priorityClassName")...); err != nil {
+			return fmt.Errorf("failed to set priorityClassName: %w", err)
+		}
+	}
+	if err := setNestedTyped(obj, config.Affinity, append(podSpec, "affinity")...); err != nil {
+		return err
+	}
+	if err := setNestedTypedSlice(obj, config.Tolerations, append(podSpec, "tolerations")...); err != nil {
+		return err
+	}
+	if err := setNestedTypedSlice(obj, config.ImagePullSecrets, append(podSpec, "imagePullSecrets")...); err != nil {
+		return err
+	}
+
+	containers, _, err := unstructured.NestedSlice(obj.Object, append(podSpec, "containers")...)
+	if err != nil {
+		return fmt.Errorf("failed to read containers: %w", err)
+	}
+	for i, c := range containers {
+		container, ok := c.(map[string]interface{})
+		if !ok || container["name"] != maasAPIContainerName {
+			continue
+		}
+		if err := customizeMaasAPIContainer(container, config); err != nil {
+			return err
+		}
+		containers[i] = container
+	}
+	return unstructured.SetNestedSlice(obj.Object, containers, append(podSpec, "containers")...)
+}
+
+// customizeMaasAPIContainer renders the image, resources and env settings into the maas-api container
+func customizeMaasAPIContainer(container map[string]interface{}, config *myappv1alpha1.MaasAPIConfig) error {
+	if config.Image != "" {
+		container["image"] = config.Image
+	}
+	if config.ImagePullPolicy != "" {
+		container["imagePullPolicy"] = string(config.ImagePullPolicy)
+	}
+	if config.Resources != nil {
+		resources, err := runtime.DefaultUnstructuredConverter.ToUnstructured(config.Resources)
+		if err != nil {
+			return fmt.Errorf("failed to convert resources: %w", err)
+		}
+		container["resources"] = resources
+	}
+
+	// Extra variables replace the embedded ones with the same name, keeping their position
+	env, _, err := unstructured.NestedSlice(container, "env")
+	if err != nil {
+		return fmt.Errorf("failed to read env: %w", err)
+	}
+	for i := range config.Env {
+		variable, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&config.Env[i])
+		if err != nil {
+			return fmt.Errorf("failed to convert env %s: %w", config.Env[i].Name, err)
+		}
+		replaced := false
+		for j, e := range env {
+			if existing, ok := e.(map[string]interface{}); ok && existing["name"] == config.Env[i].Name {
+				env[j] = variable
+				replaced = true
+			}
+		}
+		if !replaced {
+			env = append(env, variable)
+		}
+	}
+	if len(env) > 0 {
+		container["env"] = env
+	}
+	return nil
+}
+
+// setNestedTyped sets a typed API value on an unstructured object, leaving it alone when nil
+func setNestedTyped[T any](obj *unstructured.Unstructured, value *T, fields ...string) error {
+	if value == nil {
+		return nil
+	}
+	converted, err := runtime.DefaultUnstructuredConverter.ToUnstructured(value)
+	if err != nil {
+		return fmt.Errorf("failed to convert %s: %w", fields[len(fields)-1], err)
+	}
+	return unstructured.SetNestedField(obj.Object, converted, fields...)
+}
+
+// setNestedTypedSlice sets a list of typed API values on an unstructured object, leaving it alone when empty
+func setNestedTypedSlice[T any](obj *unstructured.Unstructured, values []T, fields ...string) error {
+	if len(values) == 0 {
+		return nil
+	}
+	converted := make([]interface{}, 0, len(values))
+	for i := range values {
+		value, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&values[i])
+		if err != nil {
+			return fmt.Errorf("failed to convert %s: %w", fields[len(fields)-1], err)
+		}
+		converted = append(converted, value)
+	}
+	return unstructured.SetNestedSlice(obj.Object, converted, fields...)
+}
+
+const (
+	// defaultAutoscalingMinReplicas keeps a spare maas-api pod when autoscaling is enabled
+	defaultAutoscalingMinReplicas = 2
+	// defaultAutoscalingCPUUtilization is the CPU utilization the HorizontalPodAutoscaler aims for
+	defaultAutoscalingCPUUtilization = 80
+)
+
+// maasAPIPodLabels returns the labels selecting the maas-api pods of a platform.
+// They match the selector of the maas-api Deployment in the embedded manifest, which has no
+// instance label for a platform with the legacy names since the selector cannot be changed.
+func maasAPIPodLabels(maasPlatform *myappv1alpha1.MaasPlatform) map[string]string {
+	labels := map[string]string{
+		"app.kubernetes.io/component": "api",
+		"app.kubernetes.io/name":      "maas-api",
+		"app.kubernetes.io/part-of":   "model-as-a-service",
+	}
+	if !maasPlatform.UsesLegacyNames() {
+		labels["app.kubernetes.io/instance"] = maasPlatform.Name
+	}
+	return labels
+}
+
+// customizeMaasAPIAvailability renders the replica and spread settings into the maas-api Deployment
+func customizeMaasAPIAvailability(obj *unstructured.Unstructured, maasPlatform *myappv1alpha1.MaasPlatform) error {
+	config := maasPlatform.Spec.MaasAPI
+
+	// The HorizontalPodAutoscaler owns the replicas, applying them would fight it
+	if config.Autoscaling != nil {
+		unstructured.RemoveNestedField(obj.Object, "spec", "replicas")
+	}
+
+	constraints := make([]corev1.TopologySpreadConstraint, len(config.TopologySpreadConstraints))
+	for i, constraint := range config.TopologySpreadConstraints {
+		constraint.DeepCopyInto(&constraints[i])
+		if constraints[i].LabelSelector == nil {
+			constraints[i].LabelSelector = &metav1.LabelSelector{MatchLabels: maasAPIPodLabels(maasPlatform)}
+		}
+	}
+	return setNestedTypedSlice(obj, constraints, "spec", "template", "spec", "topologySpreadConstraints")
+}
+
+// maasAPIPodDisruptionBudget builds the PodDisruptionBudget of the maas-api pods
+func maasAPIPodDisruptionBudget(maasPlatform *myappv1alpha1.MaasPlatform) (*unstructured.Unstructured, error) {
+	pdb := &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{APIVersion: policyv1.SchemeGroupVersion.String(), Kind: "PodDisruptionBudget"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      NamesFor(maasPlatform).MaasAPI,
+			Namespace: maasPlatform.Spec.APINamespace(),
+			Labels:    maasAPIComponentLabels(),
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: maasAPIPodLabels(maasPlatform)},
+		},
+	}
+
+	config := maasPlatform.Spec.MaasAPI.PodDisruptionBudget
+	switch {
+	case config.MinAvailable != nil:
+		pdb.Spec.MinAvailable = config.MinAvailable
+	case config.MaxUnavailable != nil:
+		pdb.Spec.MaxUnavailable = config.MaxUnavailable
+	default:
+		maxUnavailable := intstr.FromInt32(1)
+		pdb.Spec.MaxUnavailable = &maxUnavailable
+	}
+	return toUnstructured(pdb)
+}
+
+// maasAPIAutoscaler builds the HorizontalPodAutoscaler of the maas-api Deployment
+func maasAPIAutoscaler(maasPlatform *myappv1alpha1.MaasPlatform) (*unstructured.Unstructured, error) {
+	config := maasPlatform.Spec.MaasAPI.Autoscaling
+	minReplicas := int32(defaultAutoscalingMinReplicas)
+	if config.MinReplicas != nil {
+		minReplicas = *config.MinReplicas
+	}
+	utilization := int32(defaultAutoscalingCPUUtilization)
+	if config.TargetCPUUtilizationPercentage != nil {
+		utilization = *config.TargetCPUUtilizationPercentage
+	}
+
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{APIVersion: autoscalingv2.SchemeGroupVersion.String(), Kind: "HorizontalPodAutoscaler"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      NamesFor(maasPlatform).MaasAPI,
+			Namespace: maasPlatform.Spec.APINamespace(),
+			Labels:    maasAPIComponentLabels(),
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       NamesFor(maasPlatform).MaasAPI,
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: config.MaxReplicas,
+			Metrics: []autoscalingv2.MetricSpec{{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name: corev1.ResourceCPU,
+					Target: autoscalingv2.MetricTarget{
+						Type:               autoscalingv2.UtilizationMetricType,
+						AverageUtilization: &utilization,
+					},
+				},
+			}},
+		},
+	}
+	return toUnstructured(hpa)
+}
+
+// maasAPIComponentLabels are the labels of the objects generated for maas-api
+func maasAPIComponentLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/component": "api",
+		"app.kubernetes.io/name":      "maas-api",
+		"app.kubernetes.io/part-of":   "model-as-a-service",
+	}
+}
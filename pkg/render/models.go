@@ -0,0 +1,173 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+)
+
+// invalidLimitNameChars matches the characters replaced when a model name becomes part of a limit name
+var invalidLimitNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// Model is an LLMInferenceService a model name can resolve to.
+// Requests reach it on /<namespace>/<name>/ through the gateway.
+type Model struct {
+	Namespace string
+	Name      string
+	ModelName string
+}
+
+// limitRate is the part of a request or token rate limit the policy entries are built from
+type limitRate struct {
+	Limit    int32
+	Window   string
+	Counters []string
+}
+
+// requestRate returns the rate of a request rate limit, nil if unset
+func requestRate(config *myappv1alpha1.TierRateLimitConfig) *limitRate {
+	if config == nil {
+		return nil
+	}
+	return &limitRate{Limit: config.Limit, Window: config.Window, Counters: config.Counters}
+}
+
+// tokenRate returns the rate of a token rate limit, nil if unset
+func tokenRate(config *myappv1alpha1.TierTokenRateLimitConfig) *limitRate {
+	if config == nil {
+		return nil
+	}
+	return &limitRate{Limit: config.Limit, Window: config.Window, Counters: config.Counters}
+}
+
+// ModelsFrom returns the models of LLMInferenceServices
+func ModelsFrom(items []unstructured.Unstructured) []Model {
+	models := make([]Model, 0, len(items))
+	for _, item := range items {
+		modelName, _, _ := unstructured.NestedString(item.Object, "spec", "model", "name")
+		models = append(models, Model{Namespace: item.GetNamespace(), Name: item.GetName(), ModelName: modelName})
+	}
+	return models
+}
+
+// servicePredicate matches requests routed to an LLMInferenceService. A prefix match cannot fail
+// on short paths such as /health, which would fail every limit the predicate is part of.
+func servicePredicate(namespace, name string) string {
+	return fmt.Sprintf(`request.path.startsWith(%q)`, "/"+namespace+"/"+name+"/")
+}
+
+// modelNamePredicate matches requests for a model name. The name resolves to every
+// LLMInferenceService with that name or serving that model, and falls back to the
+// service name in the request path when nothing matches.
+func modelNamePredicate(model string, served []Model) string {
+	var matches []string
+	for _, s := range served {
+		if s.Name == model || s.ModelName == model {
+			matches = append(matches, servicePredicate(s.Namespace, s.Name))
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return fmt.Sprintf(`size(request.path.split("/")) > 2 && request.path.split("/")[2] == %q`, model)
+	case 1:
+		return matches[0]
+	}
+	return "(" + strings.Join(matches, ") || (") + ")"
+}
+
+// modelLimitPredicate matches the requests a model limit of a Tier applies to
+func modelLimitPredicate(tier *myappv1alpha1.Tier, modelLimit *myappv1alpha1.TierModelLimit, served []Model) string {
+	if ref := modelLimit.LLMInferenceServiceRef; ref != nil {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = tier.Namespace
+		}
+		return servicePredicate(namespace, ref.Name)
+	}
+	return modelNamePredicate(modelLimit.Model, served)
+}
+
+// modelLimitKey identifies a model limit in limit entry names and the Tier status
+func modelLimitKey(tier *myappv1alpha1.Tier, modelLimit *myappv1alpha1.TierModelLimit) string {
+	if ref := modelLimit.LLMInferenceServiceRef; ref != nil {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = tier.Namespace
+		}
+		return fmt.Sprintf("%s/%s", namespace, ref.Name)
+	}
+	return modelLimit.Model
+}
+
+// modelLimitName returns the limit entry name of a model limit, derived from the tier entry name.
+// The underscore cannot appear in Tier names nor in the sanitized key, so the entry of a model
+// limit never takes the name of another Tier's entry.
+func modelLimitName(tierLimitName string, tier *myappv1alpha1.Tier, modelLimit *myappv1alpha1.TierModelLimit) string {
+	key := strings.Trim(invalidLimitNameChars.ReplaceAllString(strings.ToLower(modelLimitKey(tier, modelLimit)), "-"), "-")
+	return fmt.Sprintf("%s_%s", tierLimitName, key)
+}
+
+// addTierLimits adds the policy limit entries of a Tier: one entry per model limit, and the
+// tier-wide entry restricted to spec.models and leaving out the models with their own limit.
+// tierMatch is the predicate matching the requests of the Tier, rateOf picks the request or token rate of a model limit.
+func addTierLimits(limits map[string]interface{}, tier *myappv1alpha1.Tier, tierMatch, limitName string, rate *limitRate,
+	rateOf func(*myappv1alpha1.TierModelLimit) *limitRate, served []Model) {
+	var overridden []string
+	for i := range tier.Spec.ModelLimits {
+		modelLimit := &tier.Spec.ModelLimits[i]
+		modelRate := rateOf(modelLimit)
+		if modelRate == nil {
+			continue
+		}
+		predicate := modelLimitPredicate(tier, modelLimit, served)
+		overridden = append(overridden, predicate)
+		limits[modelLimitName(limitName, tier, modelLimit)] = policyLimit(modelRate.Limit, modelRate.Window, modelRate.Counters,
+			tierMatch, predicate)
+	}
+
+	if rate == nil {
+		return
+	}
+	predicates := []string{tierMatch}
+	if len(tier.Spec.Models) > 0 {
+		var models []string
+		for _, model := range tier.Spec.Models {
+			models = append(models, fmt.Sprintf("(%s)", modelNamePredicate(model, served)))
+		}
+		predicates = append(predicates, strings.Join(models, " || "))
+	}
+	for _, predicate := range overridden {
+		predicates = append(predicates, fmt.Sprintf("!(%s)", predicate))
+	}
+	limits[limitName] = policyLimit(rate.Limit, rate.Window, rate.Counters, predicates...)
+}
+
+// modelRequestRate returns the request rate of a model limit
+func modelRequestRate(modelLimit *myappv1alpha1.TierModelLimit) *limitRate {
+	return requestRate(modelLimit.RateLimits)
+}
+
+// modelTokenRate returns the token rate of a model limit
+func modelTokenRate(modelLimit *myappv1alpha1.TierModelLimit) *limitRate {
+	return tokenRate(modelLimit.TokenRateLimits)
+}
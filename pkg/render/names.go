@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+)
+
+const (
+	// TierConfigMapName is the ConfigMap maas-api reads the tier to group mapping from,
+	// prefixed with the platform name
+	TierConfigMapName = "tier-to-group-mapping"
+
+	// RateLimitPolicyName and TokenRateLimitPolicyName are the gateway policies holding the Tier limits,
+	// prefixed with the platform name
+	RateLimitPolicyName      = "gateway-rate-limits"
+	TokenRateLimitPolicyName = "gateway-token-rate-limits"
+
+	// PlatformLabel marks every resource managed on behalf of a MaasPlatform
+	PlatformLabel = "maas-platform"
+	// ManagedByLabel marks every resource written by the operator
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	ManagedByValue = "maas-operator"
+)
+
+var (
+	// RateLimitPolicyGVK and TokenRateLimitPolicyGVK are the Kuadrant policies holding the Tier limits
+	RateLimitPolicyGVK      = schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1", Kind: "RateLimitPolicy"}
+	TokenRateLimitPolicyGVK = schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1alpha1", Kind: "TokenRateLimitPolicy"}
+	// LLMInferenceServiceGVK is the KServe kind model names resolve against
+	LLMInferenceServiceGVK = schema.GroupVersionKind{Group: "serving.kserve.io", Version: "v1alpha1", Kind: "LLMInferenceService"}
+)
+
+// Names are the names of the objects generated for a MaasPlatform.
+// They are derived from the platform name so that several platforms can share the
+// same namespaces; the webhook keeps platform names unique across namespaces.
+type Names struct {
+	Gateway              string
+	GatewayRedirectRoute string
+	GatewayAuthPolicy    string
+	MaasAPI              string
+	MaasAPIRoute         string
+	MaasAPIDatabase      string
+	TierConfigMap        string
+	RateLimitPolicy      string
+	TokenRateLimitPolicy string
+}
+
+// LegacyNames are the fixed names used before names were derived from the platform name.
+// Platforms deployed back then keep them, see myappv1alpha1.NamingAnnotation.
+var LegacyNames = Names{
+	Gateway:              "maas-default-gateway",
+	GatewayRedirectRoute: "maas-default-gateway-https-redirect",
+	GatewayAuthPolicy:    "gateway-auth-policy",
+	MaasAPI:              "maas-api",
+	MaasAPIRoute:         "maas-api-route",
+	MaasAPIDatabase:      "maas-api-db",
+	TierConfigMap:        TierConfigMapName,
+	RateLimitPolicy:      RateLimitPolicyName,
+	TokenRateLimitPolicy: TokenRateLimitPolicyName,
+}
+
+// NamesFor returns the generated object names of a MaasPlatform
+func NamesFor(maasPlatform *myappv1alpha1.MaasPlatform) Names {
+	if maasPlatform.UsesLegacyNames() {
+		return LegacyNames
+	}
+	name := maasPlatform.Name
+	return Names{
+		Gateway:              name + "-gateway",
+		GatewayRedirectRoute: name + "-gateway-https-redirect",
+		GatewayAuthPolicy:    name + "-gateway-auth-policy",
+		MaasAPI:              name + "-api",
+		MaasAPIRoute:         name + "-api-route",
+		MaasAPIDatabase:      name + "-api-db",
+		TierConfigMap:        name + "-" + TierConfigMapName,
+		RateLimitPolicy:      name + "-" + RateLimitPolicyName,
+		TokenRateLimitPolicy: name + "-" + TokenRateLimitPolicyName,
+	}
+}
+
+// PlatformLabelValue returns the value of the platform label for a MaasPlatform
+func PlatformLabelValue(maasPlatform *myappv1alpha1.MaasPlatform) string {
+	return fmt.Sprintf("%s.%s", maasPlatform.Name, maasPlatform.Namespace)
+}
+
+// SetManagedLabels labels an object as managed by the operator for the given MaasPlatform
+func SetManagedLabels(obj metav1.Object, maasPlatform *myappv1alpha1.MaasPlatform) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[ManagedByLabel] = ManagedByValue
+	labels[PlatformLabel] = PlatformLabelValue(maasPlatform)
+	obj.SetLabels(labels)
+}
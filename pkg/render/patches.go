@@ -0,0 +1,122 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+)
+
+// newPatchStatuses returns the statuses of patches before any object is rendered
+func newPatchStatuses(patches []myappv1alpha1.ObjectPatch) []myappv1alpha1.PatchStatus {
+	var statuses []myappv1alpha1.PatchStatus
+	for _, p := range patches {
+		statuses = append(statuses, myappv1alpha1.PatchStatus{
+			Target:  p.Target.String(),
+			State:   myappv1alpha1.PatchStateUnmatched,
+			Message: "No rendered object matches the target",
+		})
+	}
+	return statuses
+}
+
+// applyPatches applies the patches targeting a rendered object, in order, and records their
+// outcome in the statuses of the same index. The first failing patch fails the rendering.
+func applyPatches(obj *unstructured.Unstructured, patches []myappv1alpha1.ObjectPatch, statuses []myappv1alpha1.PatchStatus) error {
+	for i, p := range patches {
+		if !p.Target.Matches(obj.GetKind(), obj.GetNamespace(), obj.GetName()) {
+			continue
+		}
+
+		if err := patchObject(obj, p); err != nil {
+			setPatchStatus(statuses, i, myappv1alpha1.PatchStateFailed, err.Error())
+			return fmt.Errorf("failed to apply patch %d to %s: %w", i, p.Target, err)
+		}
+		setPatchStatus(statuses, i, myappv1alpha1.PatchStateApplied, "Patch applied")
+	}
+	return nil
+}
+
+// setPatchStatus updates the status of the patch at index i
+func setPatchStatus(statuses []myappv1alpha1.PatchStatus, i int, state, message string) {
+	if i >= len(statuses) {
+		return
+	}
+	statuses[i].State = state
+	statuses[i].Message = message
+}
+
+// patchObject applies a single patch to an object in place.
+// Strategic merge patches use the schema of built-in kinds and fall back to
+// JSON merge patches for other kinds.
+func patchObject(obj *unstructured.Unstructured, p myappv1alpha1.ObjectPatch) error {
+	patch, err := utilyaml.ToJSON([]byte(p.Patch))
+	if err != nil {
+		return fmt.Errorf("invalid patch: %w", err)
+	}
+
+	original, err := json.Marshal(obj.Object)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", obj.GetKind(), err)
+	}
+
+	var patched []byte
+	switch p.Type {
+	case myappv1alpha1.PatchTypeJSON6902:
+		operations, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			return fmt.Errorf("invalid JSON6902 patch: %w", err)
+		}
+		patched, err = operations.Apply(original)
+		if err != nil {
+			return err
+		}
+	default:
+		typed, err := scheme.Scheme.New(obj.GroupVersionKind())
+		if err == nil {
+			patched, err = strategicpatch.StrategicMergePatch(original, patch, typed)
+		} else {
+			patched, err = jsonpatch.MergePatch(original, patch)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// Decoding as unstructured keeps integers as int64
+	patchedObj := &unstructured.Unstructured{}
+	if err := patchedObj.UnmarshalJSON(patched); err != nil {
+		return fmt.Errorf("failed to decode patched %s: %w", obj.GetKind(), err)
+	}
+
+	// The inventory and the patch targets rely on the identity of the object
+	if patchedObj.GroupVersionKind() != obj.GroupVersionKind() ||
+		patchedObj.GetNamespace() != obj.GetNamespace() || patchedObj.GetName() != obj.GetName() {
+		return fmt.Errorf("patches cannot change the apiVersion, kind, namespace or name")
+	}
+
+	obj.Object = patchedObj.Object
+	return nil
+}
@@ -0,0 +1,339 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"bytes"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+)
+
+// Platform is the desired state of a MaasPlatform, grouped by the deployment phases that apply it
+type Platform struct {
+	// Namespaces are the namespaces of the platform components
+	Namespaces []*unstructured.Unstructured
+	// Kuadrant is the Kuadrant instance, which has to be ready before the gateways are set up
+	Kuadrant []*unstructured.Unstructured
+	// Networking are the GatewayClass, the gateways and the HTTPS redirect route
+	Networking []*unstructured.Unstructured
+	// MaasAPI are the maas-api objects, without its AuthPolicy and the tier ConfigMap the Tiers own
+	MaasAPI []*unstructured.Unstructured
+	// MaasAPIPolicies are the policies of the maas-api route, applied once maas-api is available
+	MaasAPIPolicies []*unstructured.Unstructured
+	// GatewayAuthPolicy is the authentication policy of the platform gateway
+	GatewayAuthPolicy []*unstructured.Unstructured
+
+	// UnusedComponents are the optional components the platform profile has no use for
+	UnusedComponents []string
+	// Patches are the outcome of the spec.patches entries, in spec order
+	Patches []myappv1alpha1.PatchStatus
+}
+
+// openshiftOnlyResources are the embedded objects that only make sense on OpenShift,
+// keyed by kind and name: the OpenShift GatewayClass and the OpenShift AI inference gateway.
+var openshiftOnlyResources = map[string]bool{
+	"GatewayClass/" + myappv1alpha1.DefaultGatewayClassName: true,
+	"Gateway/openshift-ai-inference":                        true,
+}
+
+// optionalComponents maps the embedded objects that can be adopted or disabled,
+// keyed by kind and name, to their component name
+var optionalComponents = map[string]string{
+	"GatewayClass/" + myappv1alpha1.DefaultGatewayClassName: myappv1alpha1.ComponentGatewayClass,
+	"Gateway/openshift-ai-inference":                        myappv1alpha1.ComponentInferenceGateway,
+	"Kuadrant/kuadrant":                                     myappv1alpha1.ComponentKuadrant,
+}
+
+// OptionalComponent returns the optional component a rendered object belongs to.
+// The objects of optional components are rendered whatever their mode; applying them
+// is up to the caller.
+func OptionalComponent(obj *unstructured.Unstructured) (string, bool) {
+	component, ok := optionalComponents[obj.GetKind()+"/"+obj.GetName()]
+	return component, ok
+}
+
+// RenderPlatform renders the objects of a MaasPlatform: its namespaces, the embedded manifests
+// customized with the spec and the objects generated from it, with spec.patches applied and the
+// managed labels set. The returned Platform carries the patch outcomes even when rendering fails.
+func RenderPlatform(maasPlatform *myappv1alpha1.MaasPlatform, facts ClusterFacts) (*Platform, error) {
+	platform := &Platform{Patches: newPatchStatuses(maasPlatform.Spec.Patches)}
+
+	if err := maasPlatform.Spec.Validate(); err != nil {
+		return platform, fmt.Errorf("invalid MaasPlatform spec: %w", err)
+	}
+
+	for _, name := range []string{
+		maasPlatform.Spec.APINamespace(),
+		maasPlatform.Spec.KuadrantNamespace(),
+		maasPlatform.Spec.GatewayNamespace(),
+	} {
+		ns := namespace(name)
+		if err := platform.finish(ns, maasPlatform); err != nil {
+			return platform, err
+		}
+		platform.Namespaces = append(platform.Namespaces, ns)
+	}
+
+	data := newManifestData(maasPlatform, ClusterDomain(maasPlatform, facts))
+	var err error
+	if platform.MaasAPI, err = platform.renderManifest("manifests/maas-api/resources.yaml", maasPlatform, data); err != nil {
+		return platform, err
+	}
+	platform.MaasAPIPolicies, platform.MaasAPI = splitByKind(platform.MaasAPI, "AuthPolicy")
+	if platform.Networking, err = platform.renderManifest("manifests/networking/resources.yaml", maasPlatform, data); err != nil {
+		return platform, err
+	}
+	platform.Kuadrant, platform.Networking = splitByKind(platform.Networking, "Kuadrant")
+	if platform.GatewayAuthPolicy, err = platform.renderManifest("manifests/policies/gateway-auth-policy.yaml", maasPlatform, data); err != nil {
+		return platform, err
+	}
+
+	// Objects generated from the spec rather than embedded
+	config := maasPlatform.Spec.MaasAPI
+	if config != nil && config.PodDisruptionBudget != nil {
+		pdb, err := maasAPIPodDisruptionBudget(maasPlatform)
+		if err != nil {
+			return platform, err
+		}
+		if err := platform.finish(pdb, maasPlatform); err != nil {
+			return platform, err
+		}
+		platform.MaasAPI = append(platform.MaasAPI, pdb)
+	}
+	if config != nil && config.Autoscaling != nil {
+		hpa, err := maasAPIAutoscaler(maasPlatform)
+		if err != nil {
+			return platform, err
+		}
+		if err := platform.finish(hpa, maasPlatform); err != nil {
+			return platform, err
+		}
+		platform.MaasAPI = append(platform.MaasAPI, hpa)
+	}
+	if maasPlatform.Spec.Gateway != nil && maasPlatform.Spec.Gateway.RedirectHTTPToHTTPS {
+		route := HTTPSRedirectRoute(maasPlatform)
+		if err := platform.finish(route, maasPlatform); err != nil {
+			return platform, err
+		}
+		platform.Networking = append(platform.Networking, route)
+	}
+
+	platform.skipUnappliedPatches(maasPlatform)
+	return platform, nil
+}
+
+// componentObjects are rendered objects of the component that applies them, none for the namespaces
+type componentObjects struct {
+	component string
+	objects   []*unstructured.Unstructured
+}
+
+// groups returns the rendered objects in apply order, grouped by component
+func (p *Platform) groups() []componentObjects {
+	return []componentObjects{
+		{"", p.Namespaces},
+		{myappv1alpha1.ComponentNetworking, p.Kuadrant},
+		{myappv1alpha1.ComponentNetworking, p.Networking},
+		{myappv1alpha1.ComponentMaasAPI, p.MaasAPI},
+		{myappv1alpha1.ComponentMaasAPI, p.MaasAPIPolicies},
+		{myappv1alpha1.ComponentGatewayAuthPolicy, p.GatewayAuthPolicy},
+	}
+}
+
+// Applied splits the rendered objects into those the operator applies, in apply order, and the
+// others: the objects of components that are not Managed and of the optional components the
+// platform adopts or disables.
+func (p *Platform) Applied(maasPlatform *myappv1alpha1.MaasPlatform) (applied, skipped []*unstructured.Unstructured) {
+	for _, group := range p.groups() {
+		managed := group.component == "" ||
+			maasPlatform.Spec.ComponentManagementState(group.component) == myappv1alpha1.ManagementStateManaged
+		for _, obj := range group.objects {
+			component, optional := OptionalComponent(obj)
+			if !managed || (optional && maasPlatform.Spec.ComponentMode(component) != myappv1alpha1.ComponentModeCreate) {
+				skipped = append(skipped, obj)
+				continue
+			}
+			applied = append(applied, obj)
+		}
+	}
+	return applied, skipped
+}
+
+// skipUnappliedPatches reports the applied patches that only match objects the operator does
+// not apply as Skipped
+func (p *Platform) skipUnappliedPatches(maasPlatform *myappv1alpha1.MaasPlatform) {
+	applied, skipped := p.Applied(maasPlatform)
+	matches := func(target myappv1alpha1.PatchTarget, objects []*unstructured.Unstructured) bool {
+		for _, obj := range objects {
+			if target.Matches(obj.GetKind(), obj.GetNamespace(), obj.GetName()) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for i, patch := range maasPlatform.Spec.Patches {
+		if i >= len(p.Patches) || p.Patches[i].State != myappv1alpha1.PatchStateApplied {
+			continue
+		}
+		if !matches(patch.Target, applied) && matches(patch.Target, skipped) {
+			setPatchStatus(p.Patches, i, myappv1alpha1.PatchStateSkipped,
+				"The target is not applied, its component is not managed or not created by the operator")
+		}
+	}
+}
+
+// renderManifest renders an embedded manifest into customized objects. Objects the
+// platform profile has no use for and the tier ConfigMap owned by the Tiers are left out.
+func (p *Platform) renderManifest(path string, maasPlatform *myappv1alpha1.MaasPlatform, data manifestData) ([]*unstructured.Unstructured, error) {
+	rendered, err := renderManifest(path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	documents, err := splitYAMLDocuments(rendered)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
+	var objects []*unstructured.Unstructured
+	for _, doc := range documents {
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(doc, &obj.Object); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+
+		// Leave out the objects the platform profile has no use for
+		if !maasPlatform.Spec.IsOpenShift() && openshiftOnlyResources[obj.GetKind()+"/"+obj.GetName()] {
+			if component, ok := OptionalComponent(obj); ok {
+				p.UnusedComponents = append(p.UnusedComponents, component)
+			}
+			continue
+		}
+
+		// The tier ConfigMap is rendered from the Tiers
+		if obj.GetKind() == "ConfigMap" && obj.GetName() == data.Names.TierConfigMap {
+			continue
+		}
+
+		// Render MaasPlatform spec settings into the object
+		if err := customizeObject(obj, maasPlatform); err != nil {
+			return nil, fmt.Errorf("failed to customize resource %s/%s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		if err := p.finish(obj, maasPlatform); err != nil {
+			return nil, err
+		}
+		objects = append(objects, obj)
+	}
+	return objects, nil
+}
+
+// finish applies the spec.patches entries to a rendered object and labels it
+func (p *Platform) finish(obj *unstructured.Unstructured, maasPlatform *myappv1alpha1.MaasPlatform) error {
+	// User patches come last so they can override anything rendered above
+	if err := applyPatches(obj, maasPlatform.Spec.Patches, p.Patches); err != nil {
+		return err
+	}
+
+	// Label the resource so it can be found again on cleanup
+	SetManagedLabels(obj, maasPlatform)
+	return nil
+}
+
+// customizeObject applies MaasPlatform spec settings to a rendered manifest object
+func customizeObject(obj *unstructured.Unstructured, maasPlatform *myappv1alpha1.MaasPlatform) error {
+	names := NamesFor(maasPlatform)
+	switch {
+	case obj.GetKind() == "Gateway" && obj.GetName() == names.Gateway:
+		return customizeGateway(obj, maasPlatform)
+	case obj.GetKind() == "HTTPRoute" && obj.GetName() == names.MaasAPIRoute:
+		return customizeMaasAPIRoute(obj, maasPlatform)
+	case obj.GetKind() == "Deployment" && obj.GetName() == names.MaasAPI:
+		return customizeMaasAPIDeployment(obj, maasPlatform)
+	}
+	return nil
+}
+
+// splitByKind separates the objects of a kind from the others, keeping their order
+func splitByKind(objects []*unstructured.Unstructured, kind string) ([]*unstructured.Unstructured, []*unstructured.Unstructured) {
+	var matching, rest []*unstructured.Unstructured
+	for _, obj := range objects {
+		if obj.GetKind() == kind {
+			matching = append(matching, obj)
+		} else {
+			rest = append(rest, obj)
+		}
+	}
+	return matching, rest
+}
+
+// namespace builds a namespace with the given name
+func namespace(name string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Namespace",
+			"metadata": map[string]interface{}{
+				"name": name,
+			},
+		},
+	}
+}
+
+// toUnstructured converts a typed object that carries its apiVersion and kind into an apply request.
+// The zero status and creation timestamp of the typed object are left out.
+func toUnstructured(obj runtime.Object) (*unstructured.Unstructured, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %T: %w", obj, err)
+	}
+	delete(content, "status")
+	unstructured.RemoveNestedField(content, "metadata", "creationTimestamp")
+	return &unstructured.Unstructured{Object: content}, nil
+}
+
+// splitYAMLDocuments splits multi-document YAML into individual documents
+func splitYAMLDocuments(data []byte) ([][]byte, error) {
+	var documents [][]byte
+	var currentDoc []byte
+
+	lines := bytes.Split(data, []byte("\n"))
+	for i, line := range lines {
+		if bytes.HasPrefix(bytes.TrimSpace(line), []byte("---")) && len(currentDoc) > 0 {
+			documents = append(documents, currentDoc)
+			currentDoc = nil
+			continue
+		}
+		currentDoc = append(currentDoc, line...)
+		if i < len(lines)-1 {
+			currentDoc = append(currentDoc, '\n')
+		}
+	}
+	if len(currentDoc) > 0 {
+		documents = append(documents, currentDoc)
+	}
+
+	return documents, nil
+}
@@ -0,0 +1,557 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+	"github.com/jland-redhat/maas-operator.git/internal/version"
+)
+
+var _ = Describe("Gateway customization", func() {
+	platform := &myappv1alpha1.MaasPlatform{ObjectMeta: metav1.ObjectMeta{Name: "maas-platform", Namespace: "default"}}
+
+	newGateway := func() *unstructured.Unstructured {
+		documents := renderedManifest("manifests/networking/resources.yaml", platform)
+
+		obj := &unstructured.Unstructured{}
+		Expect(yaml.Unmarshal(documents[0], &obj.Object)).To(Succeed())
+		Expect(obj.GetName()).To(Equal(NamesFor(platform).Gateway))
+		return obj
+	}
+
+	It("should keep the embedded gateway when no gateway config is set", func() {
+		gateway := newGateway()
+		Expect(customizeGateway(gateway, &myappv1alpha1.MaasPlatform{})).To(Succeed())
+
+		listeners, _, _ := unstructured.NestedSlice(gateway.Object, "spec", "listeners")
+		Expect(listeners).To(HaveLen(2))
+	})
+
+	It("should render hostname, TLS and extra listeners from the spec", func() {
+		gateway := newGateway()
+		maasPlatform := &myappv1alpha1.MaasPlatform{
+			Spec: myappv1alpha1.MaasPlatformSpec{
+				Gateway: &myappv1alpha1.GatewayConfig{
+					Hostname: "maas.example.org",
+					TLS: &myappv1alpha1.GatewayTLSConfig{
+						SecretName:        "maas-tls",
+						CertManagerIssuer: &myappv1alpha1.CertManagerIssuerRef{Name: "letsencrypt"},
+					},
+					Listeners: []myappv1alpha1.GatewayListener{
+						{Name: "internal", Port: 8443, Protocol: "HTTPS"},
+					},
+				},
+			},
+		}
+		Expect(customizeGateway(gateway, maasPlatform)).To(Succeed())
+
+		listeners, _, _ := unstructured.NestedSlice(gateway.Object, "spec", "listeners")
+		Expect(listeners).To(HaveLen(3))
+		for _, l := range listeners {
+			listener := l.(map[string]interface{})
+			Expect(listener["hostname"]).To(Equal("maas.example.org"))
+			if listener["protocol"] == "HTTPS" {
+				secret, _, _ := unstructured.NestedSlice(listener, "tls", "certificateRefs")
+				Expect(secret[0].(map[string]interface{})["name"]).To(Equal("maas-tls"))
+			}
+		}
+		Expect(gateway.GetAnnotations()).To(HaveKeyWithValue("cert-manager.io/cluster-issuer", "letsencrypt"))
+	})
+})
+
+var _ = Describe("maas-api customization", func() {
+	platform := &myappv1alpha1.MaasPlatform{ObjectMeta: metav1.ObjectMeta{Name: "maas-platform", Namespace: "default"}}
+
+	newDeployment := func() *unstructured.Unstructured {
+		for _, doc := range renderedManifest("manifests/maas-api/resources.yaml", platform) {
+			obj := &unstructured.Unstructured{}
+			if err := yaml.Unmarshal(doc, &obj.Object); err == nil && obj.GetKind() == "Deployment" && obj.GetName() == NamesFor(platform).MaasAPI {
+				return obj
+			}
+		}
+		Fail("maas-api Deployment not found in the embedded manifest")
+		return nil
+	}
+
+	It("should render image, replicas, resources and env from the spec", func() {
+		deployment := newDeployment()
+		replicas := int32(3)
+		maasPlatform := &myappv1alpha1.MaasPlatform{
+			Spec: myappv1alpha1.MaasPlatformSpec{
+				MaasAPI: &myappv1alpha1.MaasAPIConfig{
+					Image:    "quay.io/opendatahub/maas-api@sha256:0123",
+					Replicas: &replicas,
+					Resources: &corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+					},
+					Env: []corev1.EnvVar{
+						{Name: "PROVIDER", Value: "oidc"},
+						{Name: "LOG_LEVEL", Value: "debug"},
+					},
+					NodeSelector: map[string]string{"node-role.kubernetes.io/infra": ""},
+				},
+			},
+		}
+		Expect(customizeMaasAPIDeployment(deployment, maasPlatform)).To(Succeed())
+
+		typed := &appsv1.Deployment{}
+		Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(deployment.Object, typed)).To(Succeed())
+		Expect(*typed.Spec.Replicas).To(Equal(int32(3)))
+		Expect(typed.Spec.Template.Spec.NodeSelector).To(HaveKey("node-role.kubernetes.io/infra"))
+
+		container := typed.Spec.Template.Spec.Containers[0]
+		Expect(container.Image).To(Equal("quay.io/opendatahub/maas-api@sha256:0123"))
+		Expect(container.Resources.Limits.Memory().String()).To(Equal("512Mi"))
+		Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "PROVIDER", Value: "oidc"}))
+		Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "LOG_LEVEL", Value: "debug"}))
+		Expect(container.Env).NotTo(ContainElement(corev1.EnvVar{Name: "PROVIDER", Value: "sa-tokens"}))
+	})
+
+	It("should leave the replicas to the autoscaler and spread the pods", func() {
+		deployment := newDeployment()
+		maxReplicas := int32(5)
+		maasPlatform := &myappv1alpha1.MaasPlatform{
+			ObjectMeta: metav1.ObjectMeta{Name: "maas-platform", Namespace: "default"},
+			Spec: myappv1alpha1.MaasPlatformSpec{
+				MaasAPI: &myappv1alpha1.MaasAPIConfig{
+					Autoscaling: &myappv1alpha1.MaasAPIAutoscalingConfig{MaxReplicas: maxReplicas},
+					TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+						MaxSkew:           1,
+						TopologyKey:       "kubernetes.io/hostname",
+						WhenUnsatisfiable: corev1.ScheduleAnyway,
+					}},
+				},
+			},
+		}
+		Expect(customizeMaasAPIDeployment(deployment, maasPlatform)).To(Succeed())
+
+		typed := &appsv1.Deployment{}
+		Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(deployment.Object, typed)).To(Succeed())
+		Expect(typed.Spec.Replicas).To(BeNil())
+		Expect(typed.Spec.Template.Spec.TopologySpreadConstraints).To(HaveLen(1))
+		Expect(typed.Spec.Template.Spec.TopologySpreadConstraints[0].LabelSelector.MatchLabels).To(Equal(typed.Spec.Selector.MatchLabels))
+
+		hpa, err := maasAPIAutoscaler(maasPlatform)
+		Expect(err).NotTo(HaveOccurred())
+		typedHPA := &autoscalingv2.HorizontalPodAutoscaler{}
+		Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(hpa.Object, typedHPA)).To(Succeed())
+		Expect(typedHPA.Spec.ScaleTargetRef.Name).To(Equal(typed.Name))
+		Expect(*typedHPA.Spec.MinReplicas).To(Equal(int32(defaultAutoscalingMinReplicas)))
+		Expect(typedHPA.Spec.MaxReplicas).To(Equal(maxReplicas))
+	})
+
+	It("should select the maas-api pods with the PodDisruptionBudget", func() {
+		maasPlatform := &myappv1alpha1.MaasPlatform{
+			ObjectMeta: metav1.ObjectMeta{Name: "maas-platform", Namespace: "default"},
+			Spec: myappv1alpha1.MaasPlatformSpec{
+				MaasAPI: &myappv1alpha1.MaasAPIConfig{PodDisruptionBudget: &myappv1alpha1.PodDisruptionBudgetConfig{}},
+			},
+		}
+		deployment := &appsv1.Deployment{}
+		Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(newDeployment().Object, deployment)).To(Succeed())
+
+		pdb, err := maasAPIPodDisruptionBudget(maasPlatform)
+		Expect(err).NotTo(HaveOccurred())
+		typed := &policyv1.PodDisruptionBudget{}
+		Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(pdb.Object, typed)).To(Succeed())
+		Expect(typed.Namespace).To(Equal(deployment.Namespace))
+		Expect(typed.Spec.Selector.MatchLabels).To(Equal(deployment.Spec.Selector.MatchLabels))
+		Expect(typed.Spec.MaxUnavailable.IntValue()).To(Equal(1))
+		Expect(typed.Spec.MinAvailable).To(BeNil())
+	})
+
+	It("should size the embedded database PVC from the spec", func() {
+		size := resource.MustParse("5Gi")
+		maasPlatform := &myappv1alpha1.MaasPlatform{
+			ObjectMeta: metav1.ObjectMeta{Name: "maas-platform", Namespace: "default"},
+			Spec: myappv1alpha1.MaasPlatformSpec{
+				MaasAPI: &myappv1alpha1.MaasAPIConfig{
+					Database: &myappv1alpha1.MaasAPIDatabaseConfig{
+						Embedded: &myappv1alpha1.EmbeddedDatabaseConfig{StorageClassName: "fast", Size: &size},
+					},
+				},
+			},
+		}
+
+		var pvc *corev1.PersistentVolumeClaim
+		for _, doc := range renderedManifest("manifests/maas-api/resources.yaml", maasPlatform) {
+			obj := &unstructured.Unstructured{}
+			Expect(yaml.Unmarshal(doc, &obj.Object)).To(Succeed())
+			if obj.GetKind() == "PersistentVolumeClaim" {
+				pvc = &corev1.PersistentVolumeClaim{}
+				Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, pvc)).To(Succeed())
+			}
+		}
+		Expect(pvc).NotTo(BeNil())
+		Expect(pvc.Name).To(Equal(NamesFor(maasPlatform).MaasAPIDatabase))
+		Expect(*pvc.Spec.StorageClassName).To(Equal("fast"))
+		Expect(pvc.Spec.Resources.Requests.Storage().String()).To(Equal("5Gi"))
+	})
+
+	It("should use an external PostgreSQL database without a PVC", func() {
+		maasPlatform := &myappv1alpha1.MaasPlatform{
+			ObjectMeta: metav1.ObjectMeta{Name: "maas-platform", Namespace: "default"},
+			Spec: myappv1alpha1.MaasPlatformSpec{
+				MaasAPI: &myappv1alpha1.MaasAPIConfig{
+					Database: &myappv1alpha1.MaasAPIDatabaseConfig{
+						Type: myappv1alpha1.DatabaseTypePostgreSQL,
+						PostgreSQL: &myappv1alpha1.PostgreSQLDatabaseConfig{
+							URLSecretRef: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "maas-db"}, Key: "url"},
+						},
+					},
+				},
+			},
+		}
+
+		var deployment *appsv1.Deployment
+		for _, doc := range renderedManifest("manifests/maas-api/resources.yaml", maasPlatform) {
+			obj := &unstructured.Unstructured{}
+			Expect(yaml.Unmarshal(doc, &obj.Object)).To(Succeed())
+			Expect(obj.GetKind()).NotTo(Equal("PersistentVolumeClaim"))
+			if obj.GetKind() == "Deployment" {
+				deployment = &appsv1.Deployment{}
+				Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, deployment)).To(Succeed())
+			}
+		}
+		Expect(deployment).NotTo(BeNil())
+		Expect(deployment.Spec.Template.Spec.Volumes).To(BeEmpty())
+
+		container := deployment.Spec.Template.Spec.Containers[0]
+		Expect(container.VolumeMounts).To(BeEmpty())
+		Expect(container.Env).To(ContainElement(HaveField("Name", "DATABASE_URL")))
+		Expect(container.Env).NotTo(ContainElement(HaveField("Name", "DB_PATH")))
+	})
+
+	It("should keep the selectors of the maas-api deployed by an earlier release", func() {
+		maasPlatform := &myappv1alpha1.MaasPlatform{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "maas-platform",
+				Namespace:   "default",
+				Annotations: map[string]string{myappv1alpha1.NamingAnnotation: myappv1alpha1.NamingLegacy},
+			},
+		}
+		// The selectors of the baseline manifest, spec.selector of a Deployment cannot be changed
+		baseline := map[string]string{
+			"app.kubernetes.io/component": "api",
+			"app.kubernetes.io/name":      "maas-api",
+			"app.kubernetes.io/part-of":   "model-as-a-service",
+		}
+
+		var deployment *appsv1.Deployment
+		var service *corev1.Service
+		for _, doc := range renderedManifest("manifests/maas-api/resources.yaml", maasPlatform) {
+			obj := &unstructured.Unstructured{}
+			Expect(yaml.Unmarshal(doc, &obj.Object)).To(Succeed())
+			switch obj.GetKind() {
+			case "Deployment":
+				deployment = &appsv1.Deployment{}
+				Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, deployment)).To(Succeed())
+			case "Service":
+				service = &corev1.Service{}
+				Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, service)).To(Succeed())
+			}
+		}
+		Expect(deployment).NotTo(BeNil())
+		Expect(deployment.Name).To(Equal("maas-api"))
+		Expect(deployment.Spec.Selector.MatchLabels).To(Equal(baseline))
+		Expect(deployment.Spec.Template.Labels).To(Equal(baseline))
+		Expect(service).NotTo(BeNil())
+		Expect(service.Spec.Selector).To(Equal(baseline))
+		Expect(maasAPIPodLabels(maasPlatform)).To(Equal(baseline))
+	})
+
+	It("should keep values that look like numbers or booleans as strings", func() {
+		database := &myappv1alpha1.MaasAPIDatabaseConfig{
+			Type: myappv1alpha1.DatabaseTypePostgreSQL,
+			PostgreSQL: &myappv1alpha1.PostgreSQLDatabaseConfig{
+				URLSecretRef: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "0123"}, Key: "1"},
+			},
+		}
+		maasPlatform := &myappv1alpha1.MaasPlatform{
+			ObjectMeta: metav1.ObjectMeta{Name: "maas-platform", Namespace: "default"},
+			Spec:       myappv1alpha1.MaasPlatformSpec{MaasAPI: &myappv1alpha1.MaasAPIConfig{Database: database}},
+		}
+
+		var secretRef *corev1.SecretKeySelector
+		for _, doc := range renderedManifest("manifests/maas-api/resources.yaml", maasPlatform) {
+			obj := &unstructured.Unstructured{}
+			Expect(yaml.Unmarshal(doc, &obj.Object)).To(Succeed())
+			if obj.GetKind() == "Deployment" {
+				deployment := &appsv1.Deployment{}
+				Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, deployment)).To(Succeed())
+				for _, env := range deployment.Spec.Template.Spec.Containers[0].Env {
+					if env.Name == "DATABASE_URL" {
+						secretRef = env.ValueFrom.SecretKeyRef
+					}
+				}
+			}
+		}
+		Expect(secretRef).NotTo(BeNil())
+		Expect(secretRef.Name).To(Equal("0123"))
+		Expect(secretRef.Key).To(Equal("1"))
+
+		maasPlatform.Spec.MaasAPI.Database = &myappv1alpha1.MaasAPIDatabaseConfig{
+			Embedded: &myappv1alpha1.EmbeddedDatabaseConfig{StorageClassName: "true"},
+		}
+		var pvc *corev1.PersistentVolumeClaim
+		for _, doc := range renderedManifest("manifests/maas-api/resources.yaml", maasPlatform) {
+			obj := &unstructured.Unstructured{}
+			Expect(yaml.Unmarshal(doc, &obj.Object)).To(Succeed())
+			if obj.GetKind() == "PersistentVolumeClaim" {
+				pvc = &corev1.PersistentVolumeClaim{}
+				Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, pvc)).To(Succeed())
+			}
+		}
+		Expect(pvc).NotTo(BeNil())
+		Expect(*pvc.Spec.StorageClassName).To(Equal("true"))
+	})
+})
+
+var _ = Describe("Platform profile", func() {
+	It("should use the chosen GatewayClass and skip OpenShift-only resources with the kubernetes profile", func() {
+		maasPlatform := &myappv1alpha1.MaasPlatform{
+			ObjectMeta: metav1.ObjectMeta{Name: "maas-platform", Namespace: "maas-system"},
+			Spec: myappv1alpha1.MaasPlatformSpec{
+				Profile:       myappv1alpha1.ProfileKubernetes,
+				ClusterDomain: "localtest.me",
+				Gateway:       &myappv1alpha1.GatewayConfig{GatewayClassName: "istio"},
+			},
+		}
+
+		platform, err := RenderPlatform(maasPlatform, ClusterFacts{})
+		Expect(err).NotTo(HaveOccurred())
+
+		var kept []string
+		for _, obj := range platform.Networking {
+			kept = append(kept, obj.GetKind()+"/"+obj.GetName())
+			if obj.GetName() == NamesFor(maasPlatform).Gateway {
+				className, _, _ := unstructured.NestedString(obj.Object, "spec", "gatewayClassName")
+				Expect(className).To(Equal("istio"))
+			}
+		}
+		Expect(kept).To(ConsistOf("Gateway/maas-platform-gateway"))
+		Expect(platform.Kuadrant).To(HaveLen(1))
+		Expect(platform.UnusedComponents).To(ConsistOf(myappv1alpha1.ComponentGatewayClass, myappv1alpha1.ComponentInferenceGateway))
+	})
+})
+
+var _ = Describe("Manifest templates", func() {
+	platform := &myappv1alpha1.MaasPlatform{
+		ObjectMeta: metav1.ObjectMeta{Name: "maas-platform", Namespace: "default"},
+		Spec: myappv1alpha1.MaasPlatformSpec{
+			Namespaces: &myappv1alpha1.NamespacesConfig{API: "models"},
+		},
+	}
+
+	It("should expose the platform spec, cluster facts and operator version", func() {
+		rendered, err := renderTemplate("test", `{{ .Platform.Name }} {{ .Namespaces.API }} {{ .Cluster.Domain }} {{ .Operator.Version }}`,
+			newManifestData(platform, DefaultClusterDomain))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(rendered)).To(Equal("maas-platform models apps.example.com " + version.Version))
+	})
+
+	It("should label the rendered objects with the operator version", func() {
+		rendered, err := RenderPlatform(platform, ClusterFacts{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rendered.Networking[0].GetLabels()).To(HaveKeyWithValue("app.kubernetes.io/version", version.Version))
+		for _, obj := range rendered.MaasAPI {
+			Expect(obj.GetLabels()).To(HaveKeyWithValue("app.kubernetes.io/version", version.Version), obj.GetKind())
+		}
+	})
+
+	It("should fail on values that do not exist", func() {
+		data := newManifestData(platform, DefaultClusterDomain)
+
+		_, err := renderTemplate("test", `{{ .Cluster.Region }}`, data)
+		Expect(err).To(MatchError(ContainSubstring("failed to render manifest test")))
+
+		_, err = renderTemplate("test", `{{ .Platform.Labels.missing }}`, data)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should fail on malformed templates", func() {
+		_, err := renderTemplate("test", `{{ .Names.Gateway `, newManifestData(platform, DefaultClusterDomain))
+		Expect(err).To(MatchError(ContainSubstring("failed to parse manifest template test")))
+	})
+})
+
+var _ = Describe("Object patches", func() {
+	var platform *myappv1alpha1.MaasPlatform
+
+	renderedObject := func(path, kind string) *unstructured.Unstructured {
+		for _, doc := range renderedManifest(path, platform) {
+			obj := &unstructured.Unstructured{}
+			Expect(yaml.Unmarshal(doc, &obj.Object)).To(Succeed())
+			if obj.GetKind() == kind {
+				return obj
+			}
+		}
+		Fail("no " + kind + " in " + path)
+		return nil
+	}
+
+	// statuses starts recording the patch outcomes of the current spec
+	var patched []myappv1alpha1.PatchStatus
+	statuses := func() []myappv1alpha1.PatchStatus {
+		patched = newPatchStatuses(platform.Spec.Patches)
+		return patched
+	}
+
+	BeforeEach(func() {
+		platform = &myappv1alpha1.MaasPlatform{ObjectMeta: metav1.ObjectMeta{Name: "maas-platform", Namespace: "default"}}
+	})
+
+	It("should merge strategic merge patches into built-in kinds", func() {
+		platform.Spec.Patches = []myappv1alpha1.ObjectPatch{{
+			Target: myappv1alpha1.PatchTarget{Kind: "Deployment", Name: "maas-platform-api"},
+			Patch: `
+spec:
+  template:
+    spec:
+      containers:
+      - name: proxy
+        image: quay.io/example/proxy:latest`,
+		}}
+
+		obj := renderedObject("manifests/maas-api/resources.yaml", "Deployment")
+		Expect(applyPatches(obj, platform.Spec.Patches, statuses())).To(Succeed())
+
+		deployment := &appsv1.Deployment{}
+		Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, deployment)).To(Succeed())
+		Expect(deployment.Spec.Template.Spec.Containers).To(ConsistOf(
+			HaveField("Name", maasAPIContainerName),
+			HaveField("Name", "proxy"),
+		))
+		Expect(patched).To(HaveLen(1))
+		Expect(patched[0].State).To(Equal(myappv1alpha1.PatchStateApplied))
+	})
+
+	It("should patch and label the namespaces", func() {
+		platform.Spec.Patches = []myappv1alpha1.ObjectPatch{{
+			Target: myappv1alpha1.PatchTarget{Kind: "Namespace", Name: myappv1alpha1.DefaultAPINamespace},
+			Patch:  `{"metadata": {"labels": {"example.com/team": "platform"}}}`,
+		}}
+
+		rendered, err := RenderPlatform(platform, ClusterFacts{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rendered.Patches[0].State).To(Equal(myappv1alpha1.PatchStateApplied))
+		Expect(rendered.Namespaces[0].GetName()).To(Equal(myappv1alpha1.DefaultAPINamespace))
+		Expect(rendered.Namespaces[0].GetLabels()).To(HaveKeyWithValue("example.com/team", "platform"))
+		for _, ns := range rendered.Namespaces {
+			Expect(ns.GetLabels()).To(HaveKeyWithValue(PlatformLabel, "maas-platform.default"))
+		}
+	})
+
+	It("should report the patches of objects that are not applied as skipped", func() {
+		platform.Spec.Components = &myappv1alpha1.ComponentsConfig{
+			MaasAPI:  &myappv1alpha1.ManagedComponentConfig{ManagementState: myappv1alpha1.ManagementStateUnmanaged},
+			Kuadrant: &myappv1alpha1.ComponentConfig{Mode: myappv1alpha1.ComponentModeAdopt},
+		}
+		platform.Spec.Patches = []myappv1alpha1.ObjectPatch{
+			{
+				Target: myappv1alpha1.PatchTarget{Kind: "Deployment", Name: "maas-platform-api"},
+				Patch:  `{"spec": {"revisionHistoryLimit": 3}}`,
+			},
+			{
+				Target: myappv1alpha1.PatchTarget{Kind: "Kuadrant", Name: "kuadrant"},
+				Patch:  `{"metadata": {"annotations": {"example.com/owner": "platform-team"}}}`,
+			},
+			{
+				Target: myappv1alpha1.PatchTarget{Kind: "Gateway", Name: "maas-platform-gateway"},
+				Patch:  `{"metadata": {"annotations": {"example.com/owner": "platform-team"}}}`,
+			},
+		}
+
+		rendered, err := RenderPlatform(platform, ClusterFacts{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rendered.Patches[0].State).To(Equal(myappv1alpha1.PatchStateSkipped))
+		Expect(rendered.Patches[1].State).To(Equal(myappv1alpha1.PatchStateSkipped))
+		Expect(rendered.Patches[2].State).To(Equal(myappv1alpha1.PatchStateApplied))
+	})
+
+	It("should merge patches into kinds without a strategic merge schema", func() {
+		platform.Spec.Patches = []myappv1alpha1.ObjectPatch{{
+			Target: myappv1alpha1.PatchTarget{Kind: "Gateway", Name: "maas-platform-gateway", Namespace: "openshift-ingress"},
+			Patch:  `{"metadata": {"annotations": {"example.com/owner": "platform-team"}}}`,
+		}}
+
+		obj := renderedObject("manifests/networking/resources.yaml", "Gateway")
+		Expect(applyPatches(obj, platform.Spec.Patches, statuses())).To(Succeed())
+		Expect(obj.GetAnnotations()).To(HaveKeyWithValue("example.com/owner", "platform-team"))
+		Expect(obj.GetName()).To(Equal("maas-platform-gateway"))
+	})
+
+	It("should apply JSON6902 patches", func() {
+		platform.Spec.Patches = []myappv1alpha1.ObjectPatch{{
+			Target: myappv1alpha1.PatchTarget{Kind: "Deployment", Name: "maas-platform-api"},
+			Type:   myappv1alpha1.PatchTypeJSON6902,
+			Patch: `
+- op: add
+  path: /spec/template/spec/hostNetwork
+  value: true`,
+		}}
+
+		obj := renderedObject("manifests/maas-api/resources.yaml", "Deployment")
+		Expect(applyPatches(obj, platform.Spec.Patches, statuses())).To(Succeed())
+		hostNetwork, _, err := unstructured.NestedBool(obj.Object, "spec", "template", "spec", "hostNetwork")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hostNetwork).To(BeTrue())
+	})
+
+	It("should report failed and unmatched patches", func() {
+		platform.Spec.Patches = []myappv1alpha1.ObjectPatch{
+			{
+				Target: myappv1alpha1.PatchTarget{Kind: "Deployment", Name: "maas-platform-api"},
+				Type:   myappv1alpha1.PatchTypeJSON6902,
+				Patch:  `[{"op": "remove", "path": "/spec/missing"}]`,
+			},
+			{
+				Target: myappv1alpha1.PatchTarget{Kind: "Deployment", Name: "maas-platform-api", Namespace: "elsewhere"},
+				Patch:  `{"spec": {"replicas": 3}}`,
+			},
+		}
+
+		obj := renderedObject("manifests/maas-api/resources.yaml", "Deployment")
+		Expect(applyPatches(obj, platform.Spec.Patches, statuses())).NotTo(Succeed())
+		Expect(patched[0].State).To(Equal(myappv1alpha1.PatchStateFailed))
+		Expect(patched[1].State).To(Equal(myappv1alpha1.PatchStateUnmatched))
+		Expect(patched[1].Target).To(Equal("Deployment/elsewhere/maas-platform-api"))
+	})
+
+	It("should not let patches rename objects", func() {
+		platform.Spec.Patches = []myappv1alpha1.ObjectPatch{{
+			Target: myappv1alpha1.PatchTarget{Kind: "Deployment", Name: "maas-platform-api"},
+			Patch:  `{"metadata": {"name": "other"}}`,
+		}}
+
+		obj := renderedObject("manifests/maas-api/resources.yaml", "Deployment")
+		Expect(applyPatches(obj, platform.Spec.Patches, statuses())).To(MatchError(ContainSubstring("cannot change")))
+	})
+})
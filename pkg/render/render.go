@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package render builds the desired state of a MaasPlatform and its Tiers.
+//
+// The functions of this package are deterministic and never talk to a cluster: they take
+// the platform, its Tiers and the facts detected on the cluster, and return the objects
+// to apply. The operator reconcilers only compare the result with the cluster and apply it,
+// and other operators can import the package to produce the same objects themselves.
+package render
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+)
+
+// DefaultClusterDomain is used when the cluster domain is neither configured nor detected
+const DefaultClusterDomain = "apps.example.com"
+
+// ClusterFacts are the values of the cluster the desired state depends on
+type ClusterFacts struct {
+	// Domain is the cluster domain, used when the platform does not set spec.clusterDomain.
+	// It defaults to DefaultClusterDomain.
+	Domain string
+	// Models are the LLMInferenceServices model names in Tiers resolve against
+	Models []Model
+}
+
+// Input is what the desired state of a platform is rendered from
+type Input struct {
+	// Platform is the MaasPlatform to render
+	Platform *myappv1alpha1.MaasPlatform
+	// Tiers are the Tiers to consider, those targeting other platforms are left out
+	Tiers []myappv1alpha1.Tier
+	// Cluster holds the facts detected on the cluster
+	Cluster ClusterFacts
+}
+
+// Render returns every object the operator applies for a platform and its Tiers, in the order
+// they are applied. Components that are not Managed and optional components the platform adopts
+// or disables are left out, and so are owner references, which need the UID of the live platform.
+func Render(in Input) ([]*unstructured.Unstructured, error) {
+	// Nothing is applied for an Unmanaged or Removed platform
+	spec := &in.Platform.Spec
+	if spec.ManagementState == myappv1alpha1.ManagementStateUnmanaged || spec.ManagementState == myappv1alpha1.ManagementStateRemoved {
+		return nil, nil
+	}
+
+	platform, err := RenderPlatform(in.Platform, in.Cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	objects, _ := platform.Applied(in.Platform)
+
+	if spec.ComponentManagementState(myappv1alpha1.ComponentTierPolicies) != myappv1alpha1.ManagementStateManaged {
+		return objects, nil
+	}
+
+	// Without accepted Tiers the tier objects are only kept by the Reset cleanup policy
+	accepted, _ := PlatformTiers(in.Tiers, in.Platform)
+	if len(accepted) == 0 && TierCleanupPolicy(in.Platform) != myappv1alpha1.TierCleanupReset {
+		return objects, nil
+	}
+	tierObjects, err := RenderTiers(accepted, in.Platform, in.Cluster)
+	if err != nil {
+		return nil, err
+	}
+	return append(objects, tierObjects...), nil
+}
+
+// ClusterDomain returns the effective cluster domain of a platform: spec.clusterDomain,
+// then the detected domain, then DefaultClusterDomain
+func ClusterDomain(maasPlatform *myappv1alpha1.MaasPlatform, facts ClusterFacts) string {
+	switch {
+	case maasPlatform.Spec.ClusterDomain != "":
+		return maasPlatform.Spec.ClusterDomain
+	case facts.Domain != "":
+		return facts.Domain
+	}
+	return DefaultClusterDomain
+}
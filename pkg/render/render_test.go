@@ -0,0 +1,205 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+)
+
+// update rewrites the golden files from the current rendering: go test ./pkg/render/... -update
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+var _ = Describe("Render", func() {
+	var platform *myappv1alpha1.MaasPlatform
+
+	kinds := func(objects []*unstructured.Unstructured) []string {
+		var result []string
+		for _, obj := range objects {
+			result = append(result, obj.GetKind()+"/"+obj.GetName())
+		}
+		return result
+	}
+
+	BeforeEach(func() {
+		platform = &myappv1alpha1.MaasPlatform{ObjectMeta: metav1.ObjectMeta{Name: "maas-platform", Namespace: "default"}}
+	})
+
+	It("should render the platform objects in apply order", func() {
+		platform.Spec.Components = &myappv1alpha1.ComponentsConfig{
+			Kuadrant: &myappv1alpha1.ComponentConfig{Mode: myappv1alpha1.ComponentModeAdopt},
+		}
+		names := NamesFor(platform)
+
+		objects, err := Render(Input{Platform: platform, Cluster: ClusterFacts{Domain: "apps.test.io"}})
+		Expect(err).NotTo(HaveOccurred())
+
+		rendered := kinds(objects)
+		Expect(rendered[0]).To(Equal("Namespace/" + myappv1alpha1.DefaultAPINamespace))
+		Expect(rendered).To(ContainElements("Deployment/"+names.MaasAPI, "Gateway/"+names.Gateway))
+		Expect(rendered[len(rendered)-1]).To(HavePrefix("AuthPolicy/"))
+		Expect(rendered).NotTo(ContainElement("Kuadrant/kuadrant"))
+		Expect(rendered).NotTo(ContainElement("ConfigMap/" + names.TierConfigMap))
+
+		for _, obj := range objects {
+			if obj.GetKind() == "Gateway" && obj.GetName() == names.Gateway {
+				Expect(fmt.Sprint(obj.Object)).To(ContainSubstring("maas.apps.test.io"))
+			}
+			if obj.GetKind() != "Namespace" {
+				Expect(obj.GetLabels()).To(HaveKeyWithValue(PlatformLabel, "maas-platform.default"))
+			}
+		}
+		Expect(platform.Status.Patches).To(BeEmpty(), "the input platform is left untouched")
+	})
+
+	It("should render the tier ConfigMap and policies of the accepted Tiers", func() {
+		tiers := []myappv1alpha1.Tier{{
+			ObjectMeta: metav1.ObjectMeta{Name: "premium", Namespace: "default"},
+			Spec: myappv1alpha1.TierSpec{
+				TargetRef:  myappv1alpha1.MaasPlatformTargetRef{Name: "maas-platform"},
+				Level:      10,
+				RateLimits: &myappv1alpha1.TierRateLimitConfig{Limit: 100, Window: "1m"},
+			},
+		}}
+		names := NamesFor(platform)
+
+		objects, err := Render(Input{Platform: platform, Tiers: tiers})
+		Expect(err).NotTo(HaveOccurred())
+
+		rendered := kinds(objects)
+		Expect(rendered[len(rendered)-3:]).To(Equal([]string{
+			"ConfigMap/" + names.TierConfigMap,
+			"RateLimitPolicy/" + names.RateLimitPolicy,
+			"TokenRateLimitPolicy/" + names.TokenRateLimitPolicy,
+		}))
+		limits, _, err := unstructured.NestedMap(objects[len(objects)-2].Object, "spec", "limits")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(limits).To(HaveKey("premium"))
+	})
+
+	It("should leave out the components that are not managed", func() {
+		platform.Spec.Components = &myappv1alpha1.ComponentsConfig{
+			MaasAPI:      &myappv1alpha1.ManagedComponentConfig{ManagementState: myappv1alpha1.ManagementStateUnmanaged},
+			TierPolicies: &myappv1alpha1.ManagedComponentConfig{ManagementState: myappv1alpha1.ManagementStateRemoved},
+		}
+		platform.Spec.TierCleanup = &myappv1alpha1.TierCleanupConfig{Policy: myappv1alpha1.TierCleanupReset}
+		names := NamesFor(platform)
+
+		objects, err := Render(Input{Platform: platform})
+		Expect(err).NotTo(HaveOccurred())
+
+		rendered := kinds(objects)
+		Expect(rendered).To(ContainElements("Namespace/"+myappv1alpha1.DefaultAPINamespace, "Gateway/"+names.Gateway,
+			"AuthPolicy/"+names.GatewayAuthPolicy))
+		Expect(rendered).NotTo(ContainElement("Deployment/" + names.MaasAPI))
+		Expect(rendered).NotTo(ContainElement("AuthPolicy/" + names.MaasAPI + "-auth-policy"))
+		Expect(rendered).NotTo(ContainElement("RateLimitPolicy/" + names.RateLimitPolicy))
+
+		platform.Spec.ManagementState = myappv1alpha1.ManagementStateRemoved
+		objects, err = Render(Input{Platform: platform})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objects).To(BeEmpty())
+	})
+
+	It("should report the outcome of the patches", func() {
+		platform.Spec.Patches = []myappv1alpha1.ObjectPatch{
+			{
+				Target: myappv1alpha1.PatchTarget{Kind: "Deployment", Name: "maas-platform-api"},
+				Patch:  `{"spec": {"revisionHistoryLimit": 3}}`,
+			},
+			{
+				Target: myappv1alpha1.PatchTarget{Kind: "Deployment", Name: "missing"},
+				Patch:  `{"spec": {"replicas": 3}}`,
+			},
+		}
+
+		rendered, err := RenderPlatform(platform, ClusterFacts{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rendered.Patches).To(HaveLen(2))
+		Expect(rendered.Patches[0].State).To(Equal(myappv1alpha1.PatchStateApplied))
+		Expect(rendered.Patches[1].State).To(Equal(myappv1alpha1.PatchStateUnmatched))
+	})
+
+	It("should refuse to render an invalid spec", func() {
+		platform.Spec.Profile = myappv1alpha1.ProfileKubernetes
+
+		_, err := Render(Input{Platform: platform})
+		Expect(err).To(MatchError(ContainSubstring("clusterDomain is required")))
+	})
+
+	It("should reject input it does not know how to render", func() {
+		in := Input{}
+		err := ReadInput(&in, bytes.NewBufferString(`
+apiVersion: myapp.io.odh.maas/v1alpha1
+kind: MaasPlatform
+metadata:
+  name: maas-platform
+spec:
+  unknownField: true
+`), "default")
+		Expect(err).To(MatchError(ContainSubstring("invalid MaasPlatform maas-platform")))
+
+		err = ReadInput(&in, bytes.NewBufferString("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: other\n"), "default")
+		Expect(err).To(MatchError(ContainSubstring("unsupported kind v1 ConfigMap")))
+	})
+})
+
+// The golden files pin the complete output of Render. Every directory of testdata holds an
+// input.yaml read with ReadInput and the expected output.yaml; run the tests with -update
+// after an intended change to the rendering and review the diff.
+var _ = Describe("Golden files", func() {
+	cases, err := filepath.Glob(filepath.Join("testdata", "*", "input.yaml"))
+	if err != nil || len(cases) == 0 {
+		panic(fmt.Sprintf("no golden test cases found in testdata: %v", err))
+	}
+
+	for _, input := range cases {
+		dir := filepath.Dir(input)
+		It("should render "+filepath.Base(dir), func() {
+			file, err := os.Open(input)
+			Expect(err).NotTo(HaveOccurred())
+			defer func() { _ = file.Close() }()
+
+			in := Input{}
+			Expect(ReadInput(&in, file, "default")).To(Succeed())
+			objects, err := Render(in)
+			Expect(err).NotTo(HaveOccurred())
+
+			var rendered bytes.Buffer
+			Expect(WriteYAML(&rendered, objects)).To(Succeed())
+
+			golden := filepath.Join(dir, "output.yaml")
+			if *update {
+				Expect(os.WriteFile(golden, rendered.Bytes(), 0o644)).To(Succeed())
+			}
+			expected, err := os.ReadFile(golden)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rendered.String()).To(Equal(string(expected)), "rendering changed, run the tests with -update to accept it")
+		})
+	}
+})
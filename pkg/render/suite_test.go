@@ -0,0 +1,46 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+)
+
+// These tests use Ginkgo (BDD-style Go testing framework). Refer to
+// http://onsi.github.io/ginkgo/ to learn more about Ginkgo.
+//
+// Rendering never talks to a cluster, so there is no envtest API server here.
+
+func TestRender(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "Render Suite")
+}
+
+// renderedManifest returns the documents of an embedded manifest rendered for a platform
+func renderedManifest(path string, maasPlatform *myappv1alpha1.MaasPlatform) [][]byte {
+	rendered, err := renderManifest(path, newManifestData(maasPlatform, DefaultClusterDomain))
+	Expect(err).NotTo(HaveOccurred())
+	documents, err := splitYAMLDocuments(rendered)
+	Expect(err).NotTo(HaveOccurred())
+	return documents
+}
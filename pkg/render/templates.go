@@ -0,0 +1,140 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"text/template"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+	"github.com/jland-redhat/maas-operator.git/internal/version"
+)
+
+//go:embed manifests
+var manifestsFS embed.FS
+
+// manifestData is what the embedded manifest templates can refer to.
+// New spec fields reach the manifests through Platform, or through a derived value here
+// when the manifests should not repeat the defaulting logic.
+type manifestData struct {
+	// Platform is the MaasPlatform being rendered, with its full spec
+	Platform *myappv1alpha1.MaasPlatform
+	// Names are the generated object names of the platform
+	Names Names
+	// Namespaces are the effective component namespaces
+	Namespaces manifestNamespaces
+	// GatewayClassName is the effective GatewayClass of the platform gateway
+	GatewayClassName string
+	// Database is the effective maas-api database
+	Database manifestDatabase
+	// Cluster holds the facts detected on the cluster
+	Cluster clusterFacts
+	// Operator describes the operator doing the rendering
+	Operator operatorFacts
+}
+
+// manifestNamespaces are the namespaces of the platform components
+type manifestNamespaces struct {
+	API      string
+	Gateway  string
+	Kuadrant string
+}
+
+// manifestDatabase is the maas-api database, either the embedded PVC or an external PostgreSQL
+type manifestDatabase struct {
+	External         bool
+	StorageClassName string
+	Size             string
+	URLSecretName    string
+	URLSecretKey     string
+}
+
+// clusterFacts are the values detected on the cluster
+type clusterFacts struct {
+	Domain string
+}
+
+// operatorFacts describe the running operator
+type operatorFacts struct {
+	Version string
+}
+
+// newManifestData returns the template data of a MaasPlatform on a cluster with the given domain
+func newManifestData(maasPlatform *myappv1alpha1.MaasPlatform, clusterDomain string) manifestData {
+	return manifestData{
+		Platform: maasPlatform,
+		Names:    NamesFor(maasPlatform),
+		Namespaces: manifestNamespaces{
+			API:      maasPlatform.Spec.APINamespace(),
+			Gateway:  maasPlatform.Spec.GatewayNamespace(),
+			Kuadrant: maasPlatform.Spec.KuadrantNamespace(),
+		},
+		GatewayClassName: maasPlatform.Spec.GatewayClassName(),
+		Database:         databaseFor(maasPlatform),
+		Cluster:          clusterFacts{Domain: clusterDomain},
+		Operator:         operatorFacts{Version: version.Version},
+	}
+}
+
+// databaseFor returns the effective maas-api database of a MaasPlatform
+func databaseFor(maasPlatform *myappv1alpha1.MaasPlatform) manifestDatabase {
+	database := manifestDatabase{Size: defaultDatabaseSize}
+	if maasPlatform.Spec.MaasAPI == nil || maasPlatform.Spec.MaasAPI.Database == nil {
+		return database
+	}
+
+	config := maasPlatform.Spec.MaasAPI.Database
+	if maasPlatform.Spec.UsesExternalDatabase() && config.PostgreSQL != nil {
+		database.External = true
+		database.URLSecretName = config.PostgreSQL.URLSecretRef.Name
+		database.URLSecretKey = config.PostgreSQL.URLSecretRef.Key
+	}
+	if config.Embedded != nil {
+		database.StorageClassName = config.Embedded.StorageClassName
+		if config.Embedded.Size != nil {
+			database.Size = config.Embedded.Size.String()
+		}
+	}
+	return database
+}
+
+// renderManifest executes an embedded manifest template.
+// References to fields or map keys that do not exist fail the rendering instead of producing empty values.
+func renderManifest(path string, data manifestData) ([]byte, error) {
+	content, err := manifestsFS.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedded manifest %s: %w", path, err)
+	}
+
+	return renderTemplate(path, string(content), data)
+}
+
+// renderTemplate executes a manifest template with strict missing value handling
+func renderTemplate(name, content string, data manifestData) ([]byte, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifest template %s: %w", name, err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return nil, fmt.Errorf("failed to render manifest %s: %w", name, err)
+	}
+	return rendered.Bytes(), nil
+}
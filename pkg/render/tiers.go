@@ -0,0 +1,360 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+)
+
+// defaultLimitName is the policy limit entry written by the Reset tier cleanup policy
+const defaultLimitName = "default"
+
+// RenderTiers renders the tier to group mapping ConfigMap, the RateLimitPolicy and the
+// TokenRateLimitPolicy of a platform from its accepted Tiers, in that order. Without Tiers
+// the policies hold the defaults of the Reset tier cleanup policy, if any.
+func RenderTiers(tiers []myappv1alpha1.Tier, maasPlatform *myappv1alpha1.MaasPlatform, facts ClusterFacts) ([]*unstructured.Unstructured, error) {
+	configMap, err := buildTierConfigMap(tiers, maasPlatform)
+	if err != nil {
+		return nil, err
+	}
+	rateLimitPolicy, err := buildRateLimitPolicy(tiers, maasPlatform, facts.Models)
+	if err != nil {
+		return nil, err
+	}
+	tokenRateLimitPolicy, err := buildTokenRateLimitPolicy(tiers, maasPlatform, facts.Models)
+	if err != nil {
+		return nil, err
+	}
+	return []*unstructured.Unstructured{configMap, rateLimitPolicy, tokenRateLimitPolicy}, nil
+}
+
+// PlatformTiers splits the Tiers targeting a MaasPlatform into the accepted ones and the ones
+// rejected for an invalid spec. Tiers being deleted are left out.
+func PlatformTiers(tiers []myappv1alpha1.Tier, maasPlatform *myappv1alpha1.MaasPlatform) (accepted, rejected []myappv1alpha1.Tier) {
+	for _, tier := range tiers {
+		if tier.Spec.TargetRef.Name != maasPlatform.Name || tier.TargetNamespace() != maasPlatform.Namespace {
+			continue
+		}
+		if !tier.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := tier.Spec.Validate(); err != nil {
+			rejected = append(rejected, tier)
+			continue
+		}
+		accepted = append(accepted, tier)
+	}
+	return accepted, rejected
+}
+
+// TierCleanupPolicy returns the tier cleanup policy of a MaasPlatform, defaulting to Delete
+func TierCleanupPolicy(maasPlatform *myappv1alpha1.MaasPlatform) string {
+	if maasPlatform.Spec.TierCleanup == nil || maasPlatform.Spec.TierCleanup.Policy == "" {
+		return myappv1alpha1.TierCleanupDelete
+	}
+	return maasPlatform.Spec.TierCleanup.Policy
+}
+
+// resolveTierName returns the name used for a Tier in the generated ConfigMap and policies
+func resolveTierName(tier *myappv1alpha1.Tier) string {
+	if tier.Name == "" {
+		return tier.GetGenerateName() + "-tier"
+	}
+	return tier.Name
+}
+
+// tierGroups returns the groups mapped to a Tier, defaulting to tier-<name>-users
+// when the Tier lists no groups, users or ServiceAccounts
+func tierGroups(tier *myappv1alpha1.Tier) []string {
+	if len(tier.Spec.Groups) > 0 || len(tier.Spec.Users) > 0 || len(tier.Spec.ServiceAccounts) > 0 {
+		return tier.Spec.Groups
+	}
+	return []string{fmt.Sprintf("tier-%s-users", resolveTierName(tier))}
+}
+
+// sortTiers returns the Tiers sorted by level, then name, for consistent output.
+// The last Tier has the highest priority.
+func sortTiers(tiers []myappv1alpha1.Tier) []myappv1alpha1.Tier {
+	sortedTiers := make([]myappv1alpha1.Tier, len(tiers))
+	copy(sortedTiers, tiers)
+	sort.Slice(sortedTiers, func(i, j int) bool {
+		if sortedTiers[i].Spec.Level != sortedTiers[j].Spec.Level {
+			return sortedTiers[i].Spec.Level < sortedTiers[j].Spec.Level
+		}
+		return sortedTiers[i].Name < sortedTiers[j].Name
+	})
+	return sortedTiers
+}
+
+// tierUsers maps the users listed by the Tiers of a platform to the name of the Tier
+// they belong to, the one with the highest level when several Tiers list them
+type tierUsers map[string]string
+
+// newTierUsers collects the users and ServiceAccounts listed by the Tiers
+func newTierUsers(tiers []myappv1alpha1.Tier) tierUsers {
+	users := make(tierUsers)
+	for _, tier := range sortTiers(tiers) {
+		for _, username := range tier.Usernames() {
+			users[username] = resolveTierName(&tier)
+		}
+	}
+	return users
+}
+
+// of returns the sorted users that belong to a Tier, or all listed users for an empty name
+func (u tierUsers) of(tierName string) []string {
+	var usernames []string
+	for username, name := range u {
+		if tierName == "" || name == tierName {
+			usernames = append(usernames, username)
+		}
+	}
+	sort.Strings(usernames)
+	return usernames
+}
+
+// rateLimitName returns the RateLimitPolicy limit entry name for a Tier
+func rateLimitName(tier *myappv1alpha1.Tier) string {
+	return resolveTierName(tier)
+}
+
+// tokenRateLimitName returns the TokenRateLimitPolicy limit entry name for a Tier
+func tokenRateLimitName(tier *myappv1alpha1.Tier) string {
+	return fmt.Sprintf("%s-user-tokens", resolveTierName(tier))
+}
+
+// TierLimitStatuses lists the policy limit entries generated for a Tier in the policies of its platform
+func TierLimitStatuses(tier *myappv1alpha1.Tier, maasPlatform *myappv1alpha1.MaasPlatform) []myappv1alpha1.TierLimitStatus {
+	names := NamesFor(maasPlatform)
+	rateLimitPolicy := fmt.Sprintf("%s/%s", maasPlatform.Spec.GatewayNamespace(), names.RateLimitPolicy)
+	tokenRateLimitPolicy := fmt.Sprintf("%s/%s", maasPlatform.Spec.GatewayNamespace(), names.TokenRateLimitPolicy)
+
+	var limits []myappv1alpha1.TierLimitStatus
+	if tier.Spec.RateLimits != nil {
+		limits = append(limits, myappv1alpha1.TierLimitStatus{
+			Kind:   "RateLimitPolicy",
+			Policy: rateLimitPolicy,
+			Name:   rateLimitName(tier),
+			Limit:  tier.Spec.RateLimits.Limit,
+			Window: tier.Spec.RateLimits.Window,
+		})
+	}
+	if tier.Spec.TokenRateLimits != nil {
+		limits = append(limits, myappv1alpha1.TierLimitStatus{
+			Kind:   "TokenRateLimitPolicy",
+			Policy: tokenRateLimitPolicy,
+			Name:   tokenRateLimitName(tier),
+			Limit:  tier.Spec.TokenRateLimits.Limit,
+			Window: tier.Spec.TokenRateLimits.Window,
+		})
+	}
+	for i := range tier.Spec.ModelLimits {
+		modelLimit := &tier.Spec.ModelLimits[i]
+		if modelLimit.RateLimits != nil {
+			limits = append(limits, myappv1alpha1.TierLimitStatus{
+				Kind:   "RateLimitPolicy",
+				Policy: rateLimitPolicy,
+				Name:   modelLimitName(rateLimitName(tier), tier, modelLimit),
+				Limit:  modelLimit.RateLimits.Limit,
+				Window: modelLimit.RateLimits.Window,
+				Model:  modelLimitKey(tier, modelLimit),
+			})
+		}
+		if modelLimit.TokenRateLimits != nil {
+			limits = append(limits, myappv1alpha1.TierLimitStatus{
+				Kind:   "TokenRateLimitPolicy",
+				Policy: tokenRateLimitPolicy,
+				Name:   modelLimitName(tokenRateLimitName(tier), tier, modelLimit),
+				Limit:  modelLimit.TokenRateLimits.Limit,
+				Window: modelLimit.TokenRateLimits.Window,
+				Model:  modelLimitKey(tier, modelLimit),
+			})
+		}
+	}
+	return limits
+}
+
+// buildTierConfigMap builds the tier to group mapping ConfigMap of a platform
+func buildTierConfigMap(tiers []myappv1alpha1.Tier, maasPlatform *myappv1alpha1.MaasPlatform) (*unstructured.Unstructured, error) {
+	configMap := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      NamesFor(maasPlatform).TierConfigMap,
+			Namespace: maasPlatform.Spec.APINamespace(),
+		},
+	}
+
+	// Build tier mapping YAML
+	var tierMappings strings.Builder
+
+	for _, tier := range sortTiers(tiers) {
+		tierName := resolveTierName(&tier)
+
+		tierMappings.WriteString(fmt.Sprintf("# Tier: %s\n", tierName))
+		tierMappings.WriteString(fmt.Sprintf("- name: %s\n", tierName))
+		tierMappings.WriteString(fmt.Sprintf("  level: %d\n", tier.Spec.Level))
+		writeMappingList(&tierMappings, "groups", tierGroups(&tier))
+		if usernames := tier.Usernames(); len(usernames) > 0 {
+			writeMappingList(&tierMappings, "users", usernames)
+		}
+		tierMappings.WriteString("\n")
+	}
+
+	configMap.Data = map[string]string{
+		"tiers": tierMappings.String(),
+	}
+
+	// We can't set owner ref across namespaces, so we use labels instead
+	SetManagedLabels(configMap, maasPlatform)
+	return toUnstructured(configMap)
+}
+
+// writeMappingList writes a list of quoted values of a tier mapping entry
+func writeMappingList(tierMappings *strings.Builder, key string, values []string) {
+	if len(values) == 0 {
+		tierMappings.WriteString(fmt.Sprintf("  %s: []\n", key))
+		return
+	}
+	tierMappings.WriteString(fmt.Sprintf("  %s:\n", key))
+	for _, value := range values {
+		tierMappings.WriteString(fmt.Sprintf("    - %s\n", strconv.Quote(value)))
+	}
+}
+
+// buildRateLimitPolicy builds the RateLimitPolicy of a platform from its Tiers
+func buildRateLimitPolicy(tiers []myappv1alpha1.Tier, maasPlatform *myappv1alpha1.MaasPlatform, served []Model) (*unstructured.Unstructured, error) {
+	// Build limits from tiers
+	limits := make(map[string]interface{})
+	users := newTierUsers(tiers)
+	for _, tier := range tiers {
+		addTierLimits(limits, &tier, tierPredicate(&tier, users), rateLimitName(&tier), requestRate(tier.Spec.RateLimits), modelRequestRate, served)
+	}
+
+	// Without Tiers, the Reset cleanup policy falls back to the platform defaults
+	if cleanup := maasPlatform.Spec.TierCleanup; len(tiers) == 0 && cleanup != nil && cleanup.DefaultRateLimits != nil {
+		limits[defaultLimitName] = policyLimit(cleanup.DefaultRateLimits.Limit, cleanup.DefaultRateLimits.Window, cleanup.DefaultRateLimits.Counters)
+	}
+
+	return buildGatewayPolicy(RateLimitPolicyGVK, NamesFor(maasPlatform).RateLimitPolicy, limits, maasPlatform)
+}
+
+// buildTokenRateLimitPolicy builds the TokenRateLimitPolicy of a platform from its Tiers
+func buildTokenRateLimitPolicy(tiers []myappv1alpha1.Tier, maasPlatform *myappv1alpha1.MaasPlatform, served []Model) (*unstructured.Unstructured, error) {
+	// Build limits from tiers
+	limits := make(map[string]interface{})
+	users := newTierUsers(tiers)
+	for _, tier := range tiers {
+		addTierLimits(limits, &tier, tierPredicate(&tier, users), tokenRateLimitName(&tier), tokenRate(tier.Spec.TokenRateLimits), modelTokenRate, served)
+	}
+
+	// Without Tiers, the Reset cleanup policy falls back to the platform defaults
+	if cleanup := maasPlatform.Spec.TierCleanup; len(tiers) == 0 && cleanup != nil && cleanup.DefaultTokenRateLimits != nil {
+		limits[defaultLimitName] = policyLimit(cleanup.DefaultTokenRateLimits.Limit, cleanup.DefaultTokenRateLimits.Window, cleanup.DefaultTokenRateLimits.Counters)
+	}
+
+	return buildGatewayPolicy(TokenRateLimitPolicyGVK, NamesFor(maasPlatform).TokenRateLimitPolicy, limits, maasPlatform)
+}
+
+// buildGatewayPolicy builds a policy of the given kind targeting the platform gateway
+func buildGatewayPolicy(gvk schema.GroupVersionKind, name string, limits map[string]interface{}, maasPlatform *myappv1alpha1.MaasPlatform) (*unstructured.Unstructured, error) {
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(gvk)
+	policy.SetName(name)
+	policy.SetNamespace(maasPlatform.Spec.GatewayNamespace())
+
+	// Set spec
+	if err := unstructured.SetNestedMap(policy.Object, map[string]interface{}{
+		"targetRef": map[string]interface{}{
+			"group": "gateway.networking.k8s.io",
+			"kind":  "Gateway",
+			"name":  NamesFor(maasPlatform).Gateway,
+		},
+		"limits": limits,
+	}, "spec"); err != nil {
+		return nil, fmt.Errorf("failed to set policy spec: %w", err)
+	}
+	SetManagedLabels(policy, maasPlatform)
+	return policy, nil
+}
+
+// tierPredicate returns the policy predicate matching the requests of a Tier.
+// The users listed by the Tiers are matched by name, so they get the limits of their
+// Tier whatever tier the lookup of their groups returns.
+func tierPredicate(tier *myappv1alpha1.Tier, users tierUsers) string {
+	predicate := fmt.Sprintf(`auth.identity.tier == "%s"`, resolveTierName(tier))
+	if len(users) == 0 {
+		return predicate
+	}
+	predicate = fmt.Sprintf("(%s && !(auth.identity.username in %s))", predicate, celList(users.of("")))
+	if own := users.of(resolveTierName(tier)); len(own) > 0 {
+		predicate = fmt.Sprintf("%s || auth.identity.username in %s", predicate, celList(own))
+	}
+	return predicate
+}
+
+// celList returns a CEL list literal of strings
+func celList(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, strconv.Quote(value))
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// policyLimit builds a policy limit entry matching requests where all predicates hold,
+// or every request without predicates.
+// Only JSON types are used since the entry ends up in an unstructured object.
+func policyLimit(limit int32, window string, counters []string, predicates ...string) map[string]interface{} {
+	if len(counters) == 0 {
+		counters = []string{"auth.identity.userid"}
+	}
+	counterValues := make([]interface{}, 0, len(counters))
+	for _, counter := range counters {
+		counterValues = append(counterValues, counter)
+	}
+
+	entry := map[string]interface{}{
+		"rates": []interface{}{
+			map[string]interface{}{
+				"limit":  int64(limit),
+				"window": window,
+			},
+		},
+		"counters": counterValues,
+	}
+	if len(predicates) > 0 {
+		when := make([]interface{}, 0, len(predicates))
+		for _, predicate := range predicates {
+			when = append(when, map[string]interface{}{"predicate": predicate})
+		}
+		entry["when"] = when
+	}
+	return entry
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	myappv1alpha1 "github.com/jland-redhat/maas-operator.git/api/v1alpha1"
+)
+
+var _ = Describe("Tier rendering", func() {
+	It("should map a Tier to its groups or the default group", func() {
+		tier := &myappv1alpha1.Tier{ObjectMeta: metav1.ObjectMeta{Name: "premium"}}
+		Expect(tierGroups(tier)).To(Equal([]string{"tier-premium-users"}))
+
+		tier.Spec.Groups = []string{"cn=premium,ou=groups"}
+		Expect(tierGroups(tier)).To(Equal([]string{"cn=premium,ou=groups"}))
+
+		By("Leaving out the default group when the Tier lists users")
+		tier.Spec.Groups = nil
+		tier.Spec.Users = []string{"alice"}
+		Expect(tierGroups(tier)).To(BeEmpty())
+	})
+
+	It("should give listed users and ServiceAccounts the limits of their Tier", func() {
+		tiers := []myappv1alpha1.Tier{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "free", Namespace: "default"},
+				Spec:       myappv1alpha1.TierSpec{Level: 1, Users: []string{"alice", "bob"}},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "premium", Namespace: "default"},
+				Spec: myappv1alpha1.TierSpec{
+					Level:           2,
+					Users:           []string{"bob"},
+					ServiceAccounts: []myappv1alpha1.ServiceAccountRef{{Name: "batch"}, {Name: "ci", Namespace: "tools"}},
+				},
+			},
+			{ObjectMeta: metav1.ObjectMeta{Name: "enterprise", Namespace: "default"}, Spec: myappv1alpha1.TierSpec{Level: 3}},
+		}
+		Expect(tiers[1].Usernames()).To(Equal([]string{"bob", "system:serviceaccount:default:batch", "system:serviceaccount:tools:ci"}))
+
+		env, err := cel.NewEnv(cel.Variable("auth", cel.DynType))
+		Expect(err).NotTo(HaveOccurred())
+		users := newTierUsers(tiers)
+		matches := func(tier *myappv1alpha1.Tier, username, lookupTier string) bool {
+			ast, issues := env.Compile(tierPredicate(tier, users))
+			Expect(issues.Err()).NotTo(HaveOccurred())
+			program, err := env.Program(ast)
+			Expect(err).NotTo(HaveOccurred())
+			out, _, err := program.Eval(map[string]interface{}{
+				"auth": map[string]interface{}{"identity": map[string]interface{}{"username": username, "tier": lookupTier}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			return out.Value().(bool)
+		}
+		free, premium, enterprise := &tiers[0], &tiers[1], &tiers[2]
+
+		By("Matching listed users by name, whatever tier their groups give them")
+		Expect(matches(free, "alice", "enterprise")).To(BeTrue())
+		Expect(matches(enterprise, "alice", "enterprise")).To(BeFalse())
+		Expect(matches(premium, "system:serviceaccount:default:batch", "")).To(BeTrue())
+
+		By("Putting users listed by several Tiers in the one with the highest level")
+		Expect(matches(premium, "bob", "free")).To(BeTrue())
+		Expect(matches(free, "bob", "free")).To(BeFalse())
+
+		By("Matching the other users by the tier of their groups")
+		Expect(matches(enterprise, "carol", "enterprise")).To(BeTrue())
+		Expect(matches(free, "carol", "enterprise")).To(BeFalse())
+	})
+
+	It("should only use types an unstructured object can hold", func() {
+		tier := &myappv1alpha1.Tier{ObjectMeta: metav1.ObjectMeta{Name: "premium"}}
+		limit := policyLimit(100, "1m", nil, tierPredicate(tier, nil))
+
+		policy := &unstructured.Unstructured{}
+		policy.SetGroupVersionKind(RateLimitPolicyGVK)
+		Expect(unstructured.SetNestedMap(policy.Object, map[string]interface{}{"premium": limit}, "spec", "limits")).To(Succeed())
+		Expect(limit).To(HaveKeyWithValue("counters", []interface{}{"auth.identity.userid"}))
+		Expect(limit).To(HaveKey("when"))
+	})
+
+	It("should scope limits to models and let model limits override the tier limit", func() {
+		tier := &myappv1alpha1.Tier{
+			ObjectMeta: metav1.ObjectMeta{Name: "premium", Namespace: "default"},
+			Spec: myappv1alpha1.TierSpec{
+				TokenRateLimits: &myappv1alpha1.TierTokenRateLimitConfig{Limit: 1000000, Window: "1h"},
+				Models:          []string{"small"},
+				ModelLimits: []myappv1alpha1.TierModelLimit{{
+					Model:           "meta-llama/Llama-3-70B",
+					TokenRateLimits: &myappv1alpha1.TierTokenRateLimitConfig{Limit: 50000, Window: "1h"},
+				}},
+			},
+		}
+		served := []Model{{Namespace: "llm", Name: "llama-70b", ModelName: "meta-llama/Llama-3-70B"}}
+
+		limits := make(map[string]interface{})
+		addTierLimits(limits, tier, tierPredicate(tier, nil), tokenRateLimitName(tier), tokenRate(tier.Spec.TokenRateLimits), modelTokenRate, served)
+		Expect(limits).To(HaveLen(2))
+		Expect(limits).To(HaveKey("premium-user-tokens"))
+		Expect(limits).To(HaveKey("premium-user-tokens_meta-llama-llama-3-70b"))
+
+		modelLimit := limits["premium-user-tokens_meta-llama-llama-3-70b"].(map[string]interface{})
+		Expect(modelLimit["when"]).To(ContainElement(map[string]interface{}{"predicate": servicePredicate("llm", "llama-70b")}))
+
+		tierLimit := limits["premium-user-tokens"].(map[string]interface{})
+		Expect(tierLimit["when"]).To(HaveLen(3))
+
+		By("Leaving the request policy alone when the model limit only sets tokens")
+		limits = make(map[string]interface{})
+		addTierLimits(limits, tier, tierPredicate(tier, nil), rateLimitName(tier), requestRate(tier.Spec.RateLimits), modelRequestRate, served)
+		Expect(limits).To(BeEmpty())
+	})
+
+	It("should keep model limit entries apart from the entries of other Tiers", func() {
+		tier := &myappv1alpha1.Tier{ObjectMeta: metav1.ObjectMeta{Name: "a"}}
+		other := &myappv1alpha1.Tier{ObjectMeta: metav1.ObjectMeta{Name: "a-b"}}
+		modelLimit := &myappv1alpha1.TierModelLimit{Model: "b"}
+		Expect(modelLimitName(rateLimitName(tier), tier, modelLimit)).NotTo(Equal(rateLimitName(other)))
+	})
+
+	It("should match model requests without failing on short paths", func() {
+		env, err := cel.NewEnv(cel.Variable("request", cel.DynType), ext.Strings())
+		Expect(err).NotTo(HaveOccurred())
+		eval := func(predicate, path string) bool {
+			ast, issues := env.Compile(predicate)
+			Expect(issues.Err()).NotTo(HaveOccurred())
+			program, err := env.Program(ast)
+			Expect(err).NotTo(HaveOccurred())
+			out, _, err := program.Eval(map[string]interface{}{"request": map[string]interface{}{"path": path}})
+			Expect(err).NotTo(HaveOccurred())
+			return out.Value().(bool)
+		}
+
+		served := []Model{{Namespace: "llm", Name: "small"}}
+		for _, predicate := range []string{modelNamePredicate("small", served), modelNamePredicate("unknown", served)} {
+			Expect(eval(predicate, "/")).To(BeFalse())
+			Expect(eval(predicate, "/health")).To(BeFalse())
+		}
+		Expect(eval(modelNamePredicate("small", served), "/llm/small/v1/chat/completions")).To(BeTrue())
+		Expect(eval(modelNamePredicate("small", served), "/llm/small-2/v1/chat/completions")).To(BeFalse())
+		Expect(eval(modelNamePredicate("unknown", served), "/llm/unknown/v1/completions")).To(BeTrue())
+	})
+
+	It("should leave out the predicate of a default limit", func() {
+		Expect(policyLimit(100, "1m", []string{"auth.identity.group"})).NotTo(HaveKey("when"))
+	})
+
+	It("should render the same objects whatever the order of the Tiers", func() {
+		platform := &myappv1alpha1.MaasPlatform{ObjectMeta: metav1.ObjectMeta{Name: "maas-platform", Namespace: "default"}}
+		tiers := []myappv1alpha1.Tier{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "free", Namespace: "default"},
+				Spec: myappv1alpha1.TierSpec{
+					TargetRef:  myappv1alpha1.MaasPlatformTargetRef{Name: "maas-platform"},
+					RateLimits: &myappv1alpha1.TierRateLimitConfig{Limit: 10, Window: "1m"},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "premium", Namespace: "default"},
+				Spec: myappv1alpha1.TierSpec{
+					TargetRef:  myappv1alpha1.MaasPlatformTargetRef{Name: "maas-platform"},
+					Level:      10,
+					RateLimits: &myappv1alpha1.TierRateLimitConfig{Limit: 100, Window: "1m"},
+				},
+			},
+		}
+
+		objects, err := RenderTiers(tiers, platform, ClusterFacts{})
+		Expect(err).NotTo(HaveOccurred())
+		reversed, err := RenderTiers([]myappv1alpha1.Tier{tiers[1], tiers[0]}, platform, ClusterFacts{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reversed).To(Equal(objects))
+	})
+})